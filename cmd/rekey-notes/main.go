@@ -0,0 +1,7 @@
+package main
+
+import "github.com/PureTeamLead/go-test-assessment-developstoday/internal/app"
+
+func main() {
+	app.RekeyNotes()
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "missions_deadline_idx";
+
+ALTER TABLE missions
+    DROP COLUMN IF EXISTS overdue,
+    DROP COLUMN IF EXISTS started_at,
+    DROP COLUMN IF EXISTS deadline;
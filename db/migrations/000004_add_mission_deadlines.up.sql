@@ -0,0 +1,6 @@
+ALTER TABLE missions
+    ADD COLUMN IF NOT EXISTS deadline TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS overdue BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS "missions_deadline_idx" ON "missions" ("deadline") WHERE "state" <> 'completed';
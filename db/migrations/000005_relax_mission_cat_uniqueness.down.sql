@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "missions_active_cat_idx";
+
+ALTER TABLE missions ADD CONSTRAINT missions_cat_id_key UNIQUE (cat_id);
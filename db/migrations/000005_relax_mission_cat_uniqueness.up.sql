@@ -0,0 +1,3 @@
+ALTER TABLE missions DROP CONSTRAINT IF EXISTS missions_cat_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "missions_active_cat_idx" ON "missions" ("cat_id") WHERE "state" <> 'completed';
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "update_mission_templates_updated_at" ON "mission_templates";
+
+DROP INDEX IF EXISTS "mission_templates_name_idx";
+
+DROP TABLE IF EXISTS "mission_templates";
+
+ALTER TABLE missions DROP COLUMN IF EXISTS mission_type;
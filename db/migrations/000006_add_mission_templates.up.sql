@@ -0,0 +1,18 @@
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS mission_type VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS mission_templates (
+                                                 id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                 name VARCHAR(50) NOT NULL,
+                                                 mission_type VARCHAR(50) NOT NULL,
+                                                 default_notes TEXT NOT NULL DEFAULT '',
+                                                 targets JSONB NOT NULL,
+                                                 created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                 updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "mission_templates_name_idx" ON "mission_templates" ("name");
+
+CREATE TRIGGER update_mission_templates_updated_at
+    BEFORE UPDATE ON "mission_templates"
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
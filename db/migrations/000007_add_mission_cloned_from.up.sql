@@ -0,0 +1 @@
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS cloned_from UUID REFERENCES "missions" (id) ON DELETE SET NULL;
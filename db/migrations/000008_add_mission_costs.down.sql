@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS "update_mission_expenses_updated_at" ON "mission_expenses";
+
+DROP TABLE IF EXISTS "mission_expenses";
+
+ALTER TABLE missions
+    DROP COLUMN IF EXISTS finished_at,
+    DROP COLUMN IF EXISTS budget_cents;
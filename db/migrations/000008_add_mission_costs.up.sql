@@ -0,0 +1,22 @@
+ALTER TABLE missions
+    ADD COLUMN IF NOT EXISTS budget_cents BIGINT CHECK (budget_cents >= 0),
+    ADD COLUMN IF NOT EXISTS finished_at TIMESTAMPTZ;
+
+UPDATE missions SET finished_at = updated_at WHERE state = 'completed' AND finished_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS mission_expenses (
+                                                id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                mission_id UUID NOT NULL,
+                                                amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+                                                description TEXT NOT NULL DEFAULT '',
+                                                created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                FOREIGN KEY (mission_id) REFERENCES "missions" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "mission_expenses_mission_id_idx" ON "mission_expenses" ("mission_id");
+
+CREATE TRIGGER update_mission_expenses_updated_at
+    BEFORE UPDATE ON "mission_expenses"
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
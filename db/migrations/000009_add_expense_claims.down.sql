@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "mission_expenses_cat_id_idx";
+
+ALTER TABLE mission_expenses
+    DROP COLUMN IF EXISTS reimbursed_at,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS review_note,
+    DROP COLUMN IF EXISTS receipt_path,
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS cat_id;
+
+DROP TYPE IF EXISTS expense_status_enum;
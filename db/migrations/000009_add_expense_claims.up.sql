@@ -0,0 +1,16 @@
+CREATE TYPE expense_status_enum AS ENUM ('submitted', 'approved', 'rejected', 'reimbursed');
+
+-- expenses logged before claims were introduced already counted towards mission cost, so they are kept approved
+ALTER TABLE mission_expenses
+    ADD COLUMN IF NOT EXISTS cat_id UUID REFERENCES cats(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD',
+    ADD COLUMN IF NOT EXISTS category VARCHAR(30) NOT NULL DEFAULT 'other',
+    ADD COLUMN IF NOT EXISTS status expense_status_enum NOT NULL DEFAULT 'approved',
+    ADD COLUMN IF NOT EXISTS receipt_path TEXT,
+    ADD COLUMN IF NOT EXISTS review_note TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reimbursed_at TIMESTAMPTZ;
+
+ALTER TABLE mission_expenses ALTER COLUMN status SET DEFAULT 'submitted';
+
+CREATE INDEX IF NOT EXISTS "mission_expenses_cat_id_idx" ON "mission_expenses" ("cat_id");
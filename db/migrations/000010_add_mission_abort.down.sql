@@ -0,0 +1,28 @@
+DROP INDEX IF EXISTS "missions_deadline_idx";
+DROP INDEX IF EXISTS "missions_active_cat_idx";
+
+ALTER TABLE missions
+    DROP COLUMN IF EXISTS aborted_at,
+    DROP COLUMN IF EXISTS abort_debrief,
+    DROP COLUMN IF EXISTS abort_reason;
+
+-- enum values can't be dropped, so the type is rebuilt with aborted work folded into completed
+UPDATE missions SET state = 'completed' WHERE state = 'aborted';
+UPDATE targets SET state = 'completed' WHERE state = 'abandoned';
+
+CREATE TYPE state_enum_old AS ENUM ('started', 'completed');
+
+ALTER TABLE missions ALTER COLUMN state DROP DEFAULT;
+ALTER TABLE targets ALTER COLUMN state DROP DEFAULT;
+
+ALTER TABLE missions ALTER COLUMN state TYPE state_enum_old USING state::text::state_enum_old;
+ALTER TABLE targets ALTER COLUMN state TYPE state_enum_old USING state::text::state_enum_old;
+
+DROP TYPE state_enum;
+ALTER TYPE state_enum_old RENAME TO state_enum;
+
+ALTER TABLE missions ALTER COLUMN state SET DEFAULT 'started';
+ALTER TABLE targets ALTER COLUMN state SET DEFAULT 'started';
+
+CREATE UNIQUE INDEX IF NOT EXISTS "missions_active_cat_idx" ON "missions" ("cat_id") WHERE "state" <> 'completed';
+CREATE INDEX IF NOT EXISTS "missions_deadline_idx" ON "missions" ("deadline") WHERE "state" <> 'completed';
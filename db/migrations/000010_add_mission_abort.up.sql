@@ -0,0 +1,15 @@
+-- missions are aborted, their open targets abandoned; both live in the enum shared by missions and targets
+ALTER TYPE state_enum ADD VALUE IF NOT EXISTS 'aborted';
+ALTER TYPE state_enum ADD VALUE IF NOT EXISTS 'abandoned';
+
+ALTER TABLE missions
+    ADD COLUMN IF NOT EXISTS abort_reason VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS abort_debrief TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS aborted_at TIMESTAMPTZ;
+
+-- only started missions keep a cat busy and can become overdue
+DROP INDEX IF EXISTS "missions_active_cat_idx";
+CREATE UNIQUE INDEX IF NOT EXISTS "missions_active_cat_idx" ON "missions" ("cat_id") WHERE "state" = 'started';
+
+DROP INDEX IF EXISTS "missions_deadline_idx";
+CREATE INDEX IF NOT EXISTS "missions_deadline_idx" ON "missions" ("deadline") WHERE "state" = 'started';
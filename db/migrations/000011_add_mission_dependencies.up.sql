@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS mission_dependencies (
+                                                    mission_id UUID NOT NULL,
+                                                    prerequisite_id UUID NOT NULL,
+                                                    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                    PRIMARY KEY (mission_id, prerequisite_id),
+                                                    CHECK (mission_id <> prerequisite_id),
+                                                    FOREIGN KEY (mission_id) REFERENCES "missions" (id) ON DELETE CASCADE,
+                                                    FOREIGN KEY (prerequisite_id) REFERENCES "missions" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "mission_dependencies_prerequisite_id_idx" ON "mission_dependencies" ("prerequisite_id");
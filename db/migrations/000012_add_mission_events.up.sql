@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS mission_events (
+                                              id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                              mission_id UUID NOT NULL,
+                                              event_type VARCHAR(50) NOT NULL,
+                                              actor VARCHAR(100) NOT NULL,
+                                              payload JSONB NOT NULL DEFAULT '{}',
+                                              created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+                                              FOREIGN KEY (mission_id) REFERENCES "missions" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "mission_events_timeline_idx" ON "mission_events" ("mission_id", "created_at", "id");
@@ -0,0 +1,3 @@
+ALTER TABLE targets ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+
+UPDATE targets SET completed_at = updated_at WHERE state = 'completed' AND completed_at IS NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "mission_schedule_runs";
+
+DROP TRIGGER IF EXISTS "update_mission_schedules_updated_at" ON "mission_schedules";
+
+DROP TABLE IF EXISTS "mission_schedules";
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS mission_schedules (
+                                                 id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                 name VARCHAR(100) NOT NULL,
+                                                 mission_type VARCHAR(50) NOT NULL DEFAULT '',
+                                                 targets JSONB NOT NULL,
+                                                 budget_cents BIGINT CHECK (budget_cents >= 0),
+                                                 deadline_after_seconds BIGINT CHECK (deadline_after_seconds > 0),
+                                                 cron_expr VARCHAR(100),
+                                                 run_at TIMESTAMPTZ,
+                                                 next_run_at TIMESTAMPTZ,
+                                                 auto_assign BOOLEAN NOT NULL DEFAULT FALSE,
+                                                 paused BOOLEAN NOT NULL DEFAULT FALSE,
+                                                 created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                 updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                 CHECK ((cron_expr IS NULL) <> (run_at IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS "mission_schedules_due_idx" ON "mission_schedules" ("next_run_at") WHERE NOT "paused";
+
+CREATE TRIGGER update_mission_schedules_updated_at
+    BEFORE UPDATE ON "mission_schedules"
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
+
+-- one row per materialized occurrence, the primary key is what keeps restarts and parallel instances from duplicating missions
+CREATE TABLE IF NOT EXISTS mission_schedule_runs (
+                                                     schedule_id UUID NOT NULL,
+                                                     occurrence_at TIMESTAMPTZ NOT NULL,
+                                                     mission_id UUID,
+                                                     created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                     PRIMARY KEY (schedule_id, occurrence_at),
+                                                     FOREIGN KEY (schedule_id) REFERENCES "mission_schedules" (id) ON DELETE CASCADE,
+                                                     FOREIGN KEY (mission_id) REFERENCES "missions" (id) ON DELETE SET NULL
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "mission_approvals";
+
+ALTER TABLE missions DROP COLUMN IF EXISTS approval_status;
+
+DROP TYPE IF EXISTS approval_status_enum;
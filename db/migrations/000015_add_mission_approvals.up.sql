@@ -0,0 +1,17 @@
+CREATE TYPE approval_status_enum AS ENUM ('pending_approval', 'approved', 'rejected');
+
+-- missions created before the approval workflow may already be assigned, so they are kept approved
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS approval_status approval_status_enum NOT NULL DEFAULT 'approved';
+
+ALTER TABLE missions ALTER COLUMN approval_status SET DEFAULT 'pending_approval';
+
+-- every approver decides once per mission
+CREATE TABLE IF NOT EXISTS mission_approvals (
+                                                 mission_id UUID NOT NULL,
+                                                 approver VARCHAR(100) NOT NULL,
+                                                 decision VARCHAR(10) NOT NULL CHECK (decision IN ('approved', 'rejected')),
+                                                 comment TEXT NOT NULL DEFAULT '',
+                                                 created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                 PRIMARY KEY (mission_id, approver),
+                                                 FOREIGN KEY (mission_id) REFERENCES "missions" (id) ON DELETE CASCADE
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS "missions_deleted_at_idx";
+
+DELETE FROM targets WHERE mission_id IN (SELECT id FROM missions WHERE deleted_at IS NOT NULL);
+DELETE FROM missions WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE missions DROP COLUMN IF EXISTS deleted_at;
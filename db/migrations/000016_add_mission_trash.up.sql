@@ -0,0 +1,4 @@
+-- deleted missions stay in the trash with their targets untouched until they are restored or purged
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS "missions_deleted_at_idx" ON "missions" ("deleted_at") WHERE "deleted_at" IS NOT NULL;
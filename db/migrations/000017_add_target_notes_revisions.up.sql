@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS target_notes_revisions (
+                                                      target_id UUID NOT NULL,
+                                                      revision INT NOT NULL CHECK (revision > 0),
+                                                      author VARCHAR(100) NOT NULL,
+                                                      content TEXT NOT NULL,
+                                                      created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                      PRIMARY KEY (target_id, revision),
+                                                      FOREIGN KEY (target_id) REFERENCES "targets" (id) ON DELETE CASCADE
+);
+
+-- the current notes become the first revision, their author is not known
+INSERT INTO target_notes_revisions (target_id, revision, author, content, created_at)
+SELECT id, 1, 'system', COALESCE(notes, ''), updated_at FROM targets
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS "update_target_journal_entries_updated_at" ON "target_journal_entries";
+
+DROP TABLE IF EXISTS "target_journal_entries";
+
+DROP TYPE IF EXISTS confidence_enum;
@@ -0,0 +1,21 @@
+CREATE TYPE confidence_enum AS ENUM ('low', 'medium', 'high');
+
+CREATE TABLE IF NOT EXISTS target_journal_entries (
+                                                      id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                      target_id UUID NOT NULL,
+                                                      author VARCHAR(100) NOT NULL,
+                                                      body TEXT NOT NULL,
+                                                      location VARCHAR(200),
+                                                      confidence confidence_enum NOT NULL DEFAULT 'medium',
+                                                      observed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                      created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                      updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                      FOREIGN KEY (target_id) REFERENCES "targets" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "target_journal_entries_target_idx" ON "target_journal_entries" ("target_id", "observed_at", "id");
+
+CREATE TRIGGER update_target_journal_entries_updated_at
+    BEFORE UPDATE ON "target_journal_entries"
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
@@ -0,0 +1,6 @@
+ALTER TABLE "targets"
+    DROP COLUMN IF EXISTS last_latitude,
+    DROP COLUMN IF EXISTS last_longitude,
+    DROP COLUMN IF EXISTS last_seen_at;
+
+DROP TABLE IF EXISTS "target_sightings";
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS target_sightings (
+                                                id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                                target_id UUID NOT NULL,
+                                                cat_id UUID,
+                                                latitude DOUBLE PRECISION NOT NULL CHECK (latitude BETWEEN -90 AND 90),
+                                                longitude DOUBLE PRECISION NOT NULL CHECK (longitude BETWEEN -180 AND 180),
+                                                description TEXT NOT NULL DEFAULT '',
+                                                observed_at TIMESTAMPTZ NOT NULL,
+                                                created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                FOREIGN KEY (target_id) REFERENCES "targets" (id) ON DELETE CASCADE,
+                                                FOREIGN KEY (cat_id) REFERENCES "cats" (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS "target_sightings_target_idx" ON "target_sightings" ("target_id", "observed_at");
+
+-- last known position, kept in sync with the latest sighting when one is added
+ALTER TABLE "targets"
+    ADD COLUMN last_latitude DOUBLE PRECISION,
+    ADD COLUMN last_longitude DOUBLE PRECISION,
+    ADD COLUMN last_seen_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+-- the original spellings are not kept, the normalized codes stay in place
+DROP TABLE IF EXISTS "target_country_backfill_report";
@@ -0,0 +1,888 @@
+-- best-effort normalization of target countries to ISO 3166-1 alpha-2 codes. Keys are normalized the way
+-- country.Lookup does it: upper case, dots dropped, "&" read as "and", whitespace collapsed, leading "the" dropped
+CREATE TEMPORARY TABLE country_keys (
+                                        key TEXT PRIMARY KEY,
+                                        alpha2 CHAR(2) NOT NULL
+);
+
+INSERT INTO country_keys (key, alpha2) VALUES
+    ('ABW', 'AW'),
+    ('AD', 'AD'),
+    ('AE', 'AE'),
+    ('AF', 'AF'),
+    ('AFG', 'AF'),
+    ('AFGHANISTAN', 'AF'),
+    ('AG', 'AG'),
+    ('AGO', 'AO'),
+    ('AI', 'AI'),
+    ('AIA', 'AI'),
+    ('AL', 'AL'),
+    ('ALA', 'AX'),
+    ('ALAND ISLANDS', 'AX'),
+    ('ALB', 'AL'),
+    ('ALBANIA', 'AL'),
+    ('ALGERIA', 'DZ'),
+    ('AM', 'AM'),
+    ('AMERICA', 'US'),
+    ('AMERICAN SAMOA', 'AS'),
+    ('AND', 'AD'),
+    ('ANDORRA', 'AD'),
+    ('ANGOLA', 'AO'),
+    ('ANGUILLA', 'AI'),
+    ('ANTARCTICA', 'AQ'),
+    ('ANTIGUA AND BARBUDA', 'AG'),
+    ('AO', 'AO'),
+    ('AQ', 'AQ'),
+    ('AR', 'AR'),
+    ('ARE', 'AE'),
+    ('ARG', 'AR'),
+    ('ARGENTINA', 'AR'),
+    ('ARM', 'AM'),
+    ('ARMENIA', 'AM'),
+    ('ARUBA', 'AW'),
+    ('AS', 'AS'),
+    ('ASM', 'AS'),
+    ('AT', 'AT'),
+    ('ATA', 'AQ'),
+    ('ATF', 'TF'),
+    ('ATG', 'AG'),
+    ('AU', 'AU'),
+    ('AUS', 'AU'),
+    ('AUSTRALIA', 'AU'),
+    ('AUSTRIA', 'AT'),
+    ('AUT', 'AT'),
+    ('AW', 'AW'),
+    ('AX', 'AX'),
+    ('AZ', 'AZ'),
+    ('AZE', 'AZ'),
+    ('AZERBAIJAN', 'AZ'),
+    ('BA', 'BA'),
+    ('BAHAMAS', 'BS'),
+    ('BAHRAIN', 'BH'),
+    ('BANGLADESH', 'BD'),
+    ('BARBADOS', 'BB'),
+    ('BB', 'BB'),
+    ('BD', 'BD'),
+    ('BDI', 'BI'),
+    ('BE', 'BE'),
+    ('BEL', 'BE'),
+    ('BELARUS', 'BY'),
+    ('BELGIUM', 'BE'),
+    ('BELIZE', 'BZ'),
+    ('BEN', 'BJ'),
+    ('BENIN', 'BJ'),
+    ('BERMUDA', 'BM'),
+    ('BES', 'BQ'),
+    ('BF', 'BF'),
+    ('BFA', 'BF'),
+    ('BG', 'BG'),
+    ('BGD', 'BD'),
+    ('BGR', 'BG'),
+    ('BH', 'BH'),
+    ('BHR', 'BH'),
+    ('BHS', 'BS'),
+    ('BHUTAN', 'BT'),
+    ('BI', 'BI'),
+    ('BIH', 'BA'),
+    ('BJ', 'BJ'),
+    ('BL', 'BL'),
+    ('BLM', 'BL'),
+    ('BLR', 'BY'),
+    ('BLZ', 'BZ'),
+    ('BM', 'BM'),
+    ('BMU', 'BM'),
+    ('BN', 'BN'),
+    ('BO', 'BO'),
+    ('BOL', 'BO'),
+    ('BOLIVIA', 'BO'),
+    ('BONAIRE', 'BQ'),
+    ('BONAIRE, SINT EUSTATIUS AND SABA', 'BQ'),
+    ('BOSNIA', 'BA'),
+    ('BOSNIA AND HERZEGOVINA', 'BA'),
+    ('BOTSWANA', 'BW'),
+    ('BOUVET ISLAND', 'BV'),
+    ('BQ', 'BQ'),
+    ('BR', 'BR'),
+    ('BRA', 'BR'),
+    ('BRASIL', 'BR'),
+    ('BRAZIL', 'BR'),
+    ('BRB', 'BB'),
+    ('BRITAIN', 'GB'),
+    ('BRITISH INDIAN OCEAN TERRITORY', 'IO'),
+    ('BRITISH VIRGIN ISLANDS', 'VG'),
+    ('BRN', 'BN'),
+    ('BRUNEI', 'BN'),
+    ('BRUNEI DARUSSALAM', 'BN'),
+    ('BS', 'BS'),
+    ('BT', 'BT'),
+    ('BTN', 'BT'),
+    ('BULGARIA', 'BG'),
+    ('BURKINA FASO', 'BF'),
+    ('BURMA', 'MM'),
+    ('BURUNDI', 'BI'),
+    ('BV', 'BV'),
+    ('BVT', 'BV'),
+    ('BW', 'BW'),
+    ('BWA', 'BW'),
+    ('BY', 'BY'),
+    ('BZ', 'BZ'),
+    ('CA', 'CA'),
+    ('CABO VERDE', 'CV'),
+    ('CAF', 'CF'),
+    ('CAMBODIA', 'KH'),
+    ('CAMEROON', 'CM'),
+    ('CAN', 'CA'),
+    ('CANADA', 'CA'),
+    ('CAPE VERDE', 'CV'),
+    ('CAR', 'CF'),
+    ('CARIBBEAN NETHERLANDS', 'BQ'),
+    ('CAYMAN ISLANDS', 'KY'),
+    ('CC', 'CC'),
+    ('CCK', 'CC'),
+    ('CD', 'CD'),
+    ('CENTRAL AFRICAN REPUBLIC', 'CF'),
+    ('CF', 'CF'),
+    ('CG', 'CG'),
+    ('CH', 'CH'),
+    ('CHAD', 'TD'),
+    ('CHE', 'CH'),
+    ('CHILE', 'CL'),
+    ('CHINA', 'CN'),
+    ('CHL', 'CL'),
+    ('CHN', 'CN'),
+    ('CHRISTMAS ISLAND', 'CX'),
+    ('CI', 'CI'),
+    ('CIV', 'CI'),
+    ('CK', 'CK'),
+    ('CL', 'CL'),
+    ('CM', 'CM'),
+    ('CMR', 'CM'),
+    ('CN', 'CN'),
+    ('CO', 'CO'),
+    ('COCOS (KEELING) ISLANDS', 'CC'),
+    ('COCOS ISLANDS', 'CC'),
+    ('COD', 'CD'),
+    ('COG', 'CG'),
+    ('COK', 'CK'),
+    ('COL', 'CO'),
+    ('COLOMBIA', 'CO'),
+    ('COM', 'KM'),
+    ('COMOROS', 'KM'),
+    ('CONGO', 'CG'),
+    ('CONGO (DEM REP)', 'CD'),
+    ('CONGO (REP)', 'CG'),
+    ('CONGO-BRAZZAVILLE', 'CG'),
+    ('CONGO-KINSHASA', 'CD'),
+    ('COOK ISLANDS', 'CK'),
+    ('COSTA RICA', 'CR'),
+    ('COTE D''IVOIRE', 'CI'),
+    ('CPV', 'CV'),
+    ('CR', 'CR'),
+    ('CRI', 'CR'),
+    ('CROATIA', 'HR'),
+    ('CU', 'CU'),
+    ('CUB', 'CU'),
+    ('CUBA', 'CU'),
+    ('CURACAO', 'CW'),
+    ('CURAÇAO', 'CW'),
+    ('CUW', 'CW'),
+    ('CV', 'CV'),
+    ('CW', 'CW'),
+    ('CX', 'CX'),
+    ('CXR', 'CX'),
+    ('CY', 'CY'),
+    ('CYM', 'KY'),
+    ('CYP', 'CY'),
+    ('CYPRUS', 'CY'),
+    ('CZ', 'CZ'),
+    ('CZE', 'CZ'),
+    ('CZECH REPUBLIC', 'CZ'),
+    ('CZECHIA', 'CZ'),
+    ('CÔTE D''IVOIRE', 'CI'),
+    ('DE', 'DE'),
+    ('DEMOCRATIC PEOPLE''S REPUBLIC OF KOREA', 'KP'),
+    ('DEMOCRATIC REPUBLIC OF THE CONGO', 'CD'),
+    ('DENMARK', 'DK'),
+    ('DEU', 'DE'),
+    ('DEUTSCHLAND', 'DE'),
+    ('DJ', 'DJ'),
+    ('DJI', 'DJ'),
+    ('DJIBOUTI', 'DJ'),
+    ('DK', 'DK'),
+    ('DM', 'DM'),
+    ('DMA', 'DM'),
+    ('DNK', 'DK'),
+    ('DO', 'DO'),
+    ('DOM', 'DO'),
+    ('DOMINICA', 'DM'),
+    ('DOMINICAN REPUBLIC', 'DO'),
+    ('DPRK', 'KP'),
+    ('DR CONGO', 'CD'),
+    ('DRC', 'CD'),
+    ('DZ', 'DZ'),
+    ('DZA', 'DZ'),
+    ('EAST TIMOR', 'TL'),
+    ('EC', 'EC'),
+    ('ECU', 'EC'),
+    ('ECUADOR', 'EC'),
+    ('EE', 'EE'),
+    ('EG', 'EG'),
+    ('EGY', 'EG'),
+    ('EGYPT', 'EG'),
+    ('EH', 'EH'),
+    ('EIRE', 'IE'),
+    ('EL SALVADOR', 'SV'),
+    ('EMIRATES', 'AE'),
+    ('ENGLAND', 'GB'),
+    ('EQUATORIAL GUINEA', 'GQ'),
+    ('ER', 'ER'),
+    ('ERI', 'ER'),
+    ('ERITREA', 'ER'),
+    ('ES', 'ES'),
+    ('ESH', 'EH'),
+    ('ESP', 'ES'),
+    ('ESPAÑA', 'ES'),
+    ('EST', 'EE'),
+    ('ESTONIA', 'EE'),
+    ('ESWATINI', 'SZ'),
+    ('ET', 'ET'),
+    ('ETH', 'ET'),
+    ('ETHIOPIA', 'ET'),
+    ('FALKLAND ISLANDS', 'FK'),
+    ('FALKLAND ISLANDS (MALVINAS)', 'FK'),
+    ('FAROE ISLANDS', 'FO'),
+    ('FEDERATED STATES OF MICRONESIA', 'FM'),
+    ('FI', 'FI'),
+    ('FIJI', 'FJ'),
+    ('FIN', 'FI'),
+    ('FINLAND', 'FI'),
+    ('FJ', 'FJ'),
+    ('FJI', 'FJ'),
+    ('FK', 'FK'),
+    ('FLK', 'FK'),
+    ('FM', 'FM'),
+    ('FO', 'FO'),
+    ('FR', 'FR'),
+    ('FRA', 'FR'),
+    ('FRANCE', 'FR'),
+    ('FRENCH GUIANA', 'GF'),
+    ('FRENCH POLYNESIA', 'PF'),
+    ('FRENCH SOUTHERN TERRITORIES', 'TF'),
+    ('FRO', 'FO'),
+    ('FSM', 'FM'),
+    ('GA', 'GA'),
+    ('GAB', 'GA'),
+    ('GABON', 'GA'),
+    ('GAMBIA', 'GM'),
+    ('GB', 'GB'),
+    ('GBR', 'GB'),
+    ('GD', 'GD'),
+    ('GE', 'GE'),
+    ('GEO', 'GE'),
+    ('GEORGIA', 'GE'),
+    ('GERMANY', 'DE'),
+    ('GF', 'GF'),
+    ('GG', 'GG'),
+    ('GGY', 'GG'),
+    ('GH', 'GH'),
+    ('GHA', 'GH'),
+    ('GHANA', 'GH'),
+    ('GI', 'GI'),
+    ('GIB', 'GI'),
+    ('GIBRALTAR', 'GI'),
+    ('GIN', 'GN'),
+    ('GL', 'GL'),
+    ('GLP', 'GP'),
+    ('GM', 'GM'),
+    ('GMB', 'GM'),
+    ('GN', 'GN'),
+    ('GNB', 'GW'),
+    ('GNQ', 'GQ'),
+    ('GP', 'GP'),
+    ('GQ', 'GQ'),
+    ('GR', 'GR'),
+    ('GRC', 'GR'),
+    ('GRD', 'GD'),
+    ('GREAT BRITAIN', 'GB'),
+    ('GREECE', 'GR'),
+    ('GREENLAND', 'GL'),
+    ('GRENADA', 'GD'),
+    ('GRL', 'GL'),
+    ('GS', 'GS'),
+    ('GT', 'GT'),
+    ('GTM', 'GT'),
+    ('GU', 'GU'),
+    ('GUADELOUPE', 'GP'),
+    ('GUAM', 'GU'),
+    ('GUATEMALA', 'GT'),
+    ('GUERNSEY', 'GG'),
+    ('GUF', 'GF'),
+    ('GUINEA', 'GN'),
+    ('GUINEA-BISSAU', 'GW'),
+    ('GUM', 'GU'),
+    ('GUY', 'GY'),
+    ('GUYANA', 'GY'),
+    ('GW', 'GW'),
+    ('GY', 'GY'),
+    ('HAITI', 'HT'),
+    ('HEARD ISLAND AND MCDONALD ISLANDS', 'HM'),
+    ('HK', 'HK'),
+    ('HKG', 'HK'),
+    ('HM', 'HM'),
+    ('HMD', 'HM'),
+    ('HN', 'HN'),
+    ('HND', 'HN'),
+    ('HOLLAND', 'NL'),
+    ('HOLY SEE', 'VA'),
+    ('HONDURAS', 'HN'),
+    ('HONG KONG', 'HK'),
+    ('HR', 'HR'),
+    ('HRV', 'HR'),
+    ('HT', 'HT'),
+    ('HTI', 'HT'),
+    ('HU', 'HU'),
+    ('HUN', 'HU'),
+    ('HUNGARY', 'HU'),
+    ('ICELAND', 'IS'),
+    ('ID', 'ID'),
+    ('IDN', 'ID'),
+    ('IE', 'IE'),
+    ('IL', 'IL'),
+    ('IM', 'IM'),
+    ('IMN', 'IM'),
+    ('IN', 'IN'),
+    ('IND', 'IN'),
+    ('INDIA', 'IN'),
+    ('INDONESIA', 'ID'),
+    ('IO', 'IO'),
+    ('IOT', 'IO'),
+    ('IQ', 'IQ'),
+    ('IR', 'IR'),
+    ('IRAN', 'IR'),
+    ('IRAQ', 'IQ'),
+    ('IRELAND', 'IE'),
+    ('IRL', 'IE'),
+    ('IRN', 'IR'),
+    ('IRQ', 'IQ'),
+    ('IS', 'IS'),
+    ('ISL', 'IS'),
+    ('ISLAMIC REPUBLIC OF IRAN', 'IR'),
+    ('ISLE OF MAN', 'IM'),
+    ('ISR', 'IL'),
+    ('ISRAEL', 'IL'),
+    ('IT', 'IT'),
+    ('ITA', 'IT'),
+    ('ITALIA', 'IT'),
+    ('ITALY', 'IT'),
+    ('IVORY COAST', 'CI'),
+    ('JAM', 'JM'),
+    ('JAMAICA', 'JM'),
+    ('JAPAN', 'JP'),
+    ('JE', 'JE'),
+    ('JERSEY', 'JE'),
+    ('JEY', 'JE'),
+    ('JM', 'JM'),
+    ('JO', 'JO'),
+    ('JOR', 'JO'),
+    ('JORDAN', 'JO'),
+    ('JP', 'JP'),
+    ('JPN', 'JP'),
+    ('KAZ', 'KZ'),
+    ('KAZAKHSTAN', 'KZ'),
+    ('KE', 'KE'),
+    ('KEN', 'KE'),
+    ('KENYA', 'KE'),
+    ('KG', 'KG'),
+    ('KGZ', 'KG'),
+    ('KH', 'KH'),
+    ('KHM', 'KH'),
+    ('KI', 'KI'),
+    ('KIR', 'KI'),
+    ('KIRIBATI', 'KI'),
+    ('KM', 'KM'),
+    ('KN', 'KN'),
+    ('KNA', 'KN'),
+    ('KOR', 'KR'),
+    ('KOREA', 'KR'),
+    ('KOREA (NORTH)', 'KP'),
+    ('KOREA (SOUTH)', 'KR'),
+    ('KP', 'KP'),
+    ('KR', 'KR'),
+    ('KSA', 'SA'),
+    ('KUWAIT', 'KW'),
+    ('KW', 'KW'),
+    ('KWT', 'KW'),
+    ('KY', 'KY'),
+    ('KYRGYZ REPUBLIC', 'KG'),
+    ('KYRGYZSTAN', 'KG'),
+    ('KZ', 'KZ'),
+    ('LA', 'LA'),
+    ('LAO', 'LA'),
+    ('LAO PEOPLE''S DEMOCRATIC REPUBLIC', 'LA'),
+    ('LAOS', 'LA'),
+    ('LATVIA', 'LV'),
+    ('LB', 'LB'),
+    ('LBN', 'LB'),
+    ('LBR', 'LR'),
+    ('LBY', 'LY'),
+    ('LC', 'LC'),
+    ('LCA', 'LC'),
+    ('LEBANON', 'LB'),
+    ('LESOTHO', 'LS'),
+    ('LI', 'LI'),
+    ('LIBERIA', 'LR'),
+    ('LIBYA', 'LY'),
+    ('LIE', 'LI'),
+    ('LIECHTENSTEIN', 'LI'),
+    ('LITHUANIA', 'LT'),
+    ('LK', 'LK'),
+    ('LKA', 'LK'),
+    ('LR', 'LR'),
+    ('LS', 'LS'),
+    ('LSO', 'LS'),
+    ('LT', 'LT'),
+    ('LTU', 'LT'),
+    ('LU', 'LU'),
+    ('LUX', 'LU'),
+    ('LUXEMBOURG', 'LU'),
+    ('LV', 'LV'),
+    ('LVA', 'LV'),
+    ('LY', 'LY'),
+    ('MA', 'MA'),
+    ('MAC', 'MO'),
+    ('MACAO', 'MO'),
+    ('MACAU', 'MO'),
+    ('MACEDONIA', 'MK'),
+    ('MADAGASCAR', 'MG'),
+    ('MAF', 'MF'),
+    ('MALAWI', 'MW'),
+    ('MALAYSIA', 'MY'),
+    ('MALDIVES', 'MV'),
+    ('MALI', 'ML'),
+    ('MALTA', 'MT'),
+    ('MALVINAS', 'FK'),
+    ('MAR', 'MA'),
+    ('MARSHALL ISLANDS', 'MH'),
+    ('MARTINIQUE', 'MQ'),
+    ('MAURITANIA', 'MR'),
+    ('MAURITIUS', 'MU'),
+    ('MAYOTTE', 'YT'),
+    ('MC', 'MC'),
+    ('MCO', 'MC'),
+    ('MD', 'MD'),
+    ('MDA', 'MD'),
+    ('MDG', 'MG'),
+    ('MDV', 'MV'),
+    ('ME', 'ME'),
+    ('MEX', 'MX'),
+    ('MEXICO', 'MX'),
+    ('MF', 'MF'),
+    ('MG', 'MG'),
+    ('MH', 'MH'),
+    ('MHL', 'MH'),
+    ('MICRONESIA', 'FM'),
+    ('MK', 'MK'),
+    ('MKD', 'MK'),
+    ('ML', 'ML'),
+    ('MLI', 'ML'),
+    ('MLT', 'MT'),
+    ('MM', 'MM'),
+    ('MMR', 'MM'),
+    ('MN', 'MN'),
+    ('MNE', 'ME'),
+    ('MNG', 'MN'),
+    ('MNP', 'MP'),
+    ('MO', 'MO'),
+    ('MOLDOVA', 'MD'),
+    ('MONACO', 'MC'),
+    ('MONGOLIA', 'MN'),
+    ('MONTENEGRO', 'ME'),
+    ('MONTSERRAT', 'MS'),
+    ('MOROCCO', 'MA'),
+    ('MOZ', 'MZ'),
+    ('MOZAMBIQUE', 'MZ'),
+    ('MP', 'MP'),
+    ('MQ', 'MQ'),
+    ('MR', 'MR'),
+    ('MRT', 'MR'),
+    ('MS', 'MS'),
+    ('MSR', 'MS'),
+    ('MT', 'MT'),
+    ('MTQ', 'MQ'),
+    ('MU', 'MU'),
+    ('MUS', 'MU'),
+    ('MV', 'MV'),
+    ('MW', 'MW'),
+    ('MWI', 'MW'),
+    ('MX', 'MX'),
+    ('MY', 'MY'),
+    ('MYANMAR', 'MM'),
+    ('MYS', 'MY'),
+    ('MYT', 'YT'),
+    ('MZ', 'MZ'),
+    ('MÉXICO', 'MX'),
+    ('NA', 'NA'),
+    ('NAM', 'NA'),
+    ('NAMIBIA', 'NA'),
+    ('NAURU', 'NR'),
+    ('NC', 'NC'),
+    ('NCL', 'NC'),
+    ('NE', 'NE'),
+    ('NEPAL', 'NP'),
+    ('NER', 'NE'),
+    ('NETHERLANDS', 'NL'),
+    ('NEW CALEDONIA', 'NC'),
+    ('NEW ZEALAND', 'NZ'),
+    ('NF', 'NF'),
+    ('NFK', 'NF'),
+    ('NG', 'NG'),
+    ('NGA', 'NG'),
+    ('NI', 'NI'),
+    ('NIC', 'NI'),
+    ('NICARAGUA', 'NI'),
+    ('NIGER', 'NE'),
+    ('NIGERIA', 'NG'),
+    ('NIU', 'NU'),
+    ('NIUE', 'NU'),
+    ('NL', 'NL'),
+    ('NLD', 'NL'),
+    ('NO', 'NO'),
+    ('NOR', 'NO'),
+    ('NORFOLK ISLAND', 'NF'),
+    ('NORTH KOREA', 'KP'),
+    ('NORTH MACEDONIA', 'MK'),
+    ('NORTHERN IRELAND', 'GB'),
+    ('NORTHERN MARIANA ISLANDS', 'MP'),
+    ('NORWAY', 'NO'),
+    ('NP', 'NP'),
+    ('NPL', 'NP'),
+    ('NR', 'NR'),
+    ('NRU', 'NR'),
+    ('NU', 'NU'),
+    ('NZ', 'NZ'),
+    ('NZL', 'NZ'),
+    ('OM', 'OM'),
+    ('OMAN', 'OM'),
+    ('OMN', 'OM'),
+    ('PA', 'PA'),
+    ('PAK', 'PK'),
+    ('PAKISTAN', 'PK'),
+    ('PALAU', 'PW'),
+    ('PALESTINE', 'PS'),
+    ('PAN', 'PA'),
+    ('PANAMA', 'PA'),
+    ('PAPUA NEW GUINEA', 'PG'),
+    ('PARAGUAY', 'PY'),
+    ('PCN', 'PN'),
+    ('PE', 'PE'),
+    ('PEOPLE''S REPUBLIC OF CHINA', 'CN'),
+    ('PER', 'PE'),
+    ('PERSIA', 'IR'),
+    ('PERU', 'PE'),
+    ('PF', 'PF'),
+    ('PG', 'PG'),
+    ('PH', 'PH'),
+    ('PHILIPPINES', 'PH'),
+    ('PHL', 'PH'),
+    ('PITCAIRN', 'PN'),
+    ('PITCAIRN ISLANDS', 'PN'),
+    ('PK', 'PK'),
+    ('PL', 'PL'),
+    ('PLURINATIONAL STATE OF BOLIVIA', 'BO'),
+    ('PLW', 'PW'),
+    ('PM', 'PM'),
+    ('PN', 'PN'),
+    ('PNG', 'PG'),
+    ('POL', 'PL'),
+    ('POLAND', 'PL'),
+    ('PORTUGAL', 'PT'),
+    ('PR', 'PR'),
+    ('PRC', 'CN'),
+    ('PRI', 'PR'),
+    ('PRK', 'KP'),
+    ('PRT', 'PT'),
+    ('PRY', 'PY'),
+    ('PS', 'PS'),
+    ('PSE', 'PS'),
+    ('PT', 'PT'),
+    ('PUERTO RICO', 'PR'),
+    ('PW', 'PW'),
+    ('PY', 'PY'),
+    ('PYF', 'PF'),
+    ('QA', 'QA'),
+    ('QAT', 'QA'),
+    ('QATAR', 'QA'),
+    ('RE', 'RE'),
+    ('REPUBLIC OF IRELAND', 'IE'),
+    ('REPUBLIC OF KOREA', 'KR'),
+    ('REPUBLIC OF MOLDOVA', 'MD'),
+    ('REPUBLIC OF THE CONGO', 'CG'),
+    ('REU', 'RE'),
+    ('REUNION', 'RE'),
+    ('RO', 'RO'),
+    ('ROMANIA', 'RO'),
+    ('ROU', 'RO'),
+    ('RS', 'RS'),
+    ('RU', 'RU'),
+    ('RUS', 'RU'),
+    ('RUSSIA', 'RU'),
+    ('RUSSIAN FEDERATION', 'RU'),
+    ('RW', 'RW'),
+    ('RWA', 'RW'),
+    ('RWANDA', 'RW'),
+    ('RÉUNION', 'RE'),
+    ('SA', 'SA'),
+    ('SAINT BARTHELEMY', 'BL'),
+    ('SAINT BARTHÉLEMY', 'BL'),
+    ('SAINT HELENA', 'SH'),
+    ('SAINT HELENA, ASCENSION AND TRISTAN DA CUNHA', 'SH'),
+    ('SAINT KITTS AND NEVIS', 'KN'),
+    ('SAINT LUCIA', 'LC'),
+    ('SAINT MARTIN', 'MF'),
+    ('SAINT MARTIN (FRENCH PART)', 'MF'),
+    ('SAINT PIERRE AND MIQUELON', 'PM'),
+    ('SAINT VINCENT AND THE GRENADINES', 'VC'),
+    ('SAMOA', 'WS'),
+    ('SAN MARINO', 'SM'),
+    ('SAO TOME AND PRINCIPE', 'ST'),
+    ('SAU', 'SA'),
+    ('SAUDI ARABIA', 'SA'),
+    ('SB', 'SB'),
+    ('SC', 'SC'),
+    ('SCOTLAND', 'GB'),
+    ('SD', 'SD'),
+    ('SDN', 'SD'),
+    ('SE', 'SE'),
+    ('SEN', 'SN'),
+    ('SENEGAL', 'SN'),
+    ('SERBIA', 'RS'),
+    ('SEYCHELLES', 'SC'),
+    ('SG', 'SG'),
+    ('SGP', 'SG'),
+    ('SGS', 'GS'),
+    ('SH', 'SH'),
+    ('SHN', 'SH'),
+    ('SI', 'SI'),
+    ('SIERRA LEONE', 'SL'),
+    ('SINGAPORE', 'SG'),
+    ('SINT MAARTEN', 'SX'),
+    ('SINT MAARTEN (DUTCH PART)', 'SX'),
+    ('SJ', 'SJ'),
+    ('SJM', 'SJ'),
+    ('SK', 'SK'),
+    ('SL', 'SL'),
+    ('SLB', 'SB'),
+    ('SLE', 'SL'),
+    ('SLOVAKIA', 'SK'),
+    ('SLOVENIA', 'SI'),
+    ('SLV', 'SV'),
+    ('SM', 'SM'),
+    ('SMR', 'SM'),
+    ('SN', 'SN'),
+    ('SO', 'SO'),
+    ('SOLOMON ISLANDS', 'SB'),
+    ('SOM', 'SO'),
+    ('SOMALIA', 'SO'),
+    ('SOUTH AFRICA', 'ZA'),
+    ('SOUTH GEORGIA', 'GS'),
+    ('SOUTH GEORGIA AND THE SOUTH SANDWICH ISLANDS', 'GS'),
+    ('SOUTH KOREA', 'KR'),
+    ('SOUTH SUDAN', 'SS'),
+    ('SPAIN', 'ES'),
+    ('SPM', 'PM'),
+    ('SR', 'SR'),
+    ('SRB', 'RS'),
+    ('SRI LANKA', 'LK'),
+    ('SS', 'SS'),
+    ('SSD', 'SS'),
+    ('ST', 'ST'),
+    ('ST BARTHELEMY', 'BL'),
+    ('ST HELENA', 'SH'),
+    ('ST KITTS AND NEVIS', 'KN'),
+    ('ST LUCIA', 'LC'),
+    ('ST MARTIN', 'MF'),
+    ('ST PIERRE AND MIQUELON', 'PM'),
+    ('ST VINCENT', 'VC'),
+    ('STATE OF PALESTINE', 'PS'),
+    ('STP', 'ST'),
+    ('SUDAN', 'SD'),
+    ('SUR', 'SR'),
+    ('SURINAME', 'SR'),
+    ('SV', 'SV'),
+    ('SVALBARD AND JAN MAYEN', 'SJ'),
+    ('SVK', 'SK'),
+    ('SVN', 'SI'),
+    ('SWAZILAND', 'SZ'),
+    ('SWE', 'SE'),
+    ('SWEDEN', 'SE'),
+    ('SWITZERLAND', 'CH'),
+    ('SWZ', 'SZ'),
+    ('SX', 'SX'),
+    ('SXM', 'SX'),
+    ('SY', 'SY'),
+    ('SYC', 'SC'),
+    ('SYR', 'SY'),
+    ('SYRIA', 'SY'),
+    ('SYRIAN ARAB REPUBLIC', 'SY'),
+    ('SZ', 'SZ'),
+    ('SÃO TOMÉ AND PRÍNCIPE', 'ST'),
+    ('TAIWAN', 'TW'),
+    ('TAJIKISTAN', 'TJ'),
+    ('TANZANIA', 'TZ'),
+    ('TC', 'TC'),
+    ('TCA', 'TC'),
+    ('TCD', 'TD'),
+    ('TD', 'TD'),
+    ('TF', 'TF'),
+    ('TG', 'TG'),
+    ('TGO', 'TG'),
+    ('TH', 'TH'),
+    ('THA', 'TH'),
+    ('THAILAND', 'TH'),
+    ('TIMOR-LESTE', 'TL'),
+    ('TJ', 'TJ'),
+    ('TJK', 'TJ'),
+    ('TK', 'TK'),
+    ('TKL', 'TK'),
+    ('TKM', 'TM'),
+    ('TL', 'TL'),
+    ('TLS', 'TL'),
+    ('TM', 'TM'),
+    ('TN', 'TN'),
+    ('TO', 'TO'),
+    ('TOGO', 'TG'),
+    ('TOKELAU', 'TK'),
+    ('TON', 'TO'),
+    ('TONGA', 'TO'),
+    ('TR', 'TR'),
+    ('TRINIDAD AND TOBAGO', 'TT'),
+    ('TT', 'TT'),
+    ('TTO', 'TT'),
+    ('TUN', 'TN'),
+    ('TUNISIA', 'TN'),
+    ('TUR', 'TR'),
+    ('TURKEY', 'TR'),
+    ('TURKIYE', 'TR'),
+    ('TURKMENISTAN', 'TM'),
+    ('TURKS AND CAICOS ISLANDS', 'TC'),
+    ('TUV', 'TV'),
+    ('TUVALU', 'TV'),
+    ('TV', 'TV'),
+    ('TW', 'TW'),
+    ('TWN', 'TW'),
+    ('TZ', 'TZ'),
+    ('TZA', 'TZ'),
+    ('TÜRKIYE', 'TR'),
+    ('UA', 'UA'),
+    ('UAE', 'AE'),
+    ('UG', 'UG'),
+    ('UGA', 'UG'),
+    ('UGANDA', 'UG'),
+    ('UK', 'GB'),
+    ('UKR', 'UA'),
+    ('UKRAINE', 'UA'),
+    ('UM', 'UM'),
+    ('UMI', 'UM'),
+    ('UNITED ARAB EMIRATES', 'AE'),
+    ('UNITED KINGDOM', 'GB'),
+    ('UNITED KINGDOM OF GREAT BRITAIN AND NORTHERN IRELAND', 'GB'),
+    ('UNITED REPUBLIC OF TANZANIA', 'TZ'),
+    ('UNITED STATES', 'US'),
+    ('UNITED STATES MINOR OUTLYING ISLANDS', 'UM'),
+    ('UNITED STATES OF AMERICA', 'US'),
+    ('UNITED STATES VIRGIN ISLANDS', 'VI'),
+    ('URUGUAY', 'UY'),
+    ('URY', 'UY'),
+    ('US', 'US'),
+    ('US VIRGIN ISLANDS', 'VI'),
+    ('USA', 'US'),
+    ('UY', 'UY'),
+    ('UZ', 'UZ'),
+    ('UZB', 'UZ'),
+    ('UZBEKISTAN', 'UZ'),
+    ('VA', 'VA'),
+    ('VANUATU', 'VU'),
+    ('VAT', 'VA'),
+    ('VATICAN', 'VA'),
+    ('VATICAN CITY', 'VA'),
+    ('VC', 'VC'),
+    ('VCT', 'VC'),
+    ('VE', 'VE'),
+    ('VEN', 'VE'),
+    ('VENEZUELA', 'VE'),
+    ('VG', 'VG'),
+    ('VGB', 'VG'),
+    ('VI', 'VI'),
+    ('VIET NAM', 'VN'),
+    ('VIETNAM', 'VN'),
+    ('VIR', 'VI'),
+    ('VIRGIN ISLANDS (BRITISH)', 'VG'),
+    ('VIRGIN ISLANDS (US)', 'VI'),
+    ('VN', 'VN'),
+    ('VNM', 'VN'),
+    ('VU', 'VU'),
+    ('VUT', 'VU'),
+    ('WALES', 'GB'),
+    ('WALLIS AND FUTUNA', 'WF'),
+    ('WESTERN SAHARA', 'EH'),
+    ('WF', 'WF'),
+    ('WLF', 'WF'),
+    ('WS', 'WS'),
+    ('WSM', 'WS'),
+    ('YE', 'YE'),
+    ('YEM', 'YE'),
+    ('YEMEN', 'YE'),
+    ('YT', 'YT'),
+    ('ZA', 'ZA'),
+    ('ZAF', 'ZA'),
+    ('ZAMBIA', 'ZM'),
+    ('ZIMBABWE', 'ZW'),
+    ('ZM', 'ZM'),
+    ('ZMB', 'ZM'),
+    ('ZW', 'ZW'),
+    ('ZWE', 'ZW'),
+    ('ÅLAND', 'AX'),
+    ('ÅLAND ISLANDS', 'AX');
+
+CREATE TEMPORARY TABLE target_country_keys AS
+SELECT id, regexp_replace(trim(regexp_replace(replace(replace(upper(country), '.', ''), '&', ' AND '), '\s+', ' ', 'g')), '^THE ', '') AS key
+FROM targets;
+
+-- "U S A" is a spaced code, it is tried only when the key itself matches nothing
+UPDATE targets t
+SET country = m.alpha2
+FROM (
+         SELECT tk.id, COALESCE(exact.alpha2, spaced.alpha2) AS alpha2
+         FROM target_country_keys tk
+                  LEFT JOIN country_keys exact ON exact.key = tk.key
+                  LEFT JOIN country_keys spaced ON spaced.key = replace(tk.key, ' ', '')
+     ) m
+WHERE t.id = m.id AND m.alpha2 IS NOT NULL AND t.country <> m.alpha2;
+
+-- values nothing matched stay as they are and are listed here for a manual fix
+CREATE TABLE IF NOT EXISTS target_country_backfill_report (
+                                                              target_id UUID PRIMARY KEY,
+                                                              mission_id UUID NOT NULL,
+                                                              country VARCHAR(50) NOT NULL,
+                                                              reported_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                                              FOREIGN KEY (target_id) REFERENCES "targets" (id) ON DELETE CASCADE
+);
+
+INSERT INTO target_country_backfill_report (target_id, mission_id, country)
+SELECT t.id, t.mission_id, t.country
+FROM targets t
+WHERE t.country NOT IN (SELECT alpha2 FROM country_keys)
+ON CONFLICT (target_id) DO UPDATE SET country = EXCLUDED.country, reported_at = now();
+
+DO $$
+DECLARE
+    unmatched INT;
+BEGIN
+    SELECT count(*) INTO unmatched FROM target_country_backfill_report;
+    IF unmatched > 0 THEN
+        RAISE NOTICE '% target(s) have a country that is not ISO 3166-1, see target_country_backfill_report', unmatched;
+    END IF;
+END $$;
+
+DROP TABLE target_country_keys;
+DROP TABLE country_keys;
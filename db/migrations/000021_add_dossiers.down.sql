@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "targets_dossier_idx";
+
+ALTER TABLE "targets" DROP COLUMN IF EXISTS dossier_id;
+
+DROP TRIGGER IF EXISTS "update_dossiers_updated_at" ON "dossiers";
+
+DROP TABLE IF EXISTS "dossiers";
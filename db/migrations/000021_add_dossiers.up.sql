@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS dossiers (
+                                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                        name VARCHAR(100) NOT NULL,
+                                        country VARCHAR(50) NOT NULL,
+                                        aliases JSONB NOT NULL DEFAULT '[]',
+                                        description TEXT NOT NULL DEFAULT '',
+                                        created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                        updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "dossiers_country_idx" ON "dossiers" ("country");
+
+CREATE TRIGGER update_dossiers_updated_at
+    BEFORE UPDATE ON "dossiers"
+    FOR EACH ROW
+EXECUTE PROCEDURE update_updated_at_column();
+
+ALTER TABLE "targets" ADD COLUMN dossier_id UUID REFERENCES "dossiers" (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS "targets_dossier_idx" ON "targets" ("dossier_id");
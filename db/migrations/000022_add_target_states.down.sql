@@ -0,0 +1,15 @@
+-- open states fold back into started, neutralized counts as completed and escaped as abandoned
+ALTER TABLE targets ALTER COLUMN state DROP DEFAULT;
+ALTER TABLE targets ALTER COLUMN state TYPE state_enum USING (
+    CASE state
+        WHEN 'identified' THEN 'started'
+        WHEN 'under_surveillance' THEN 'started'
+        WHEN 'contacted' THEN 'started'
+        WHEN 'neutralized' THEN 'completed'
+        WHEN 'escaped' THEN 'abandoned'
+        ELSE state::text
+    END
+)::state_enum;
+ALTER TABLE targets ALTER COLUMN state SET DEFAULT 'started';
+
+DROP TYPE IF EXISTS target_state_enum;
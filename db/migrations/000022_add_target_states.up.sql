@@ -0,0 +1,16 @@
+-- targets get their own state type, state_enum keeps serving missions ('abandoned' stays in it unused,
+-- enum values can't be dropped)
+CREATE TYPE target_state_enum AS ENUM (
+    'started',
+    'identified',
+    'under_surveillance',
+    'contacted',
+    'neutralized',
+    'escaped',
+    'completed',
+    'abandoned'
+);
+
+ALTER TABLE targets ALTER COLUMN state DROP DEFAULT;
+ALTER TABLE targets ALTER COLUMN state TYPE target_state_enum USING state::text::target_state_enum;
+ALTER TABLE targets ALTER COLUMN state SET DEFAULT 'started';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "target_journal_entries_search_idx";
+ALTER TABLE target_journal_entries DROP COLUMN IF EXISTS search_vector;
+
+DROP INDEX IF EXISTS "targets_search_idx";
+ALTER TABLE targets DROP COLUMN IF EXISTS search_vector;
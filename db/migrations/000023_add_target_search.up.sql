@@ -0,0 +1,13 @@
+-- names are proper nouns and are indexed without stemming, notes and journal entries as english text
+ALTER TABLE targets ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, name), 'A') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(notes, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "targets_search_idx" ON "targets" USING GIN ("search_vector");
+
+ALTER TABLE target_journal_entries ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english'::regconfig, body), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "target_journal_entries_search_idx" ON "target_journal_entries" USING GIN ("search_vector");
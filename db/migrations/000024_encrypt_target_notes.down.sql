@@ -0,0 +1,23 @@
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM targets WHERE notes_key_id IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM target_notes_revisions WHERE content_key_id IS NOT NULL) THEN
+        RAISE EXCEPTION 'target notes are still encrypted, run rekey-notes -decrypt first';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS "targets_search_idx";
+ALTER TABLE targets DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE targets ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, name), 'A') ||
+    setweight(to_tsvector('english'::regconfig, COALESCE(notes, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "targets_search_idx" ON "targets" USING GIN ("search_vector");
+
+DROP INDEX IF EXISTS "targets_notes_index_idx";
+ALTER TABLE targets DROP COLUMN IF EXISTS notes_index;
+
+ALTER TABLE target_notes_revisions DROP COLUMN IF EXISTS content_key_id;
+ALTER TABLE targets DROP COLUMN IF EXISTS notes_key_id;
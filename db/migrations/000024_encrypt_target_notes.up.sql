@@ -0,0 +1,19 @@
+-- a NULL key id marks notes still stored in plain text, they are encrypted by rekey-notes
+ALTER TABLE targets ADD COLUMN IF NOT EXISTS notes_key_id VARCHAR(64);
+ALTER TABLE target_notes_revisions ADD COLUMN IF NOT EXISTS content_key_id VARCHAR(64);
+
+-- encrypted notes are searched through keyed hashes of their words written by the application,
+-- notes stored before encryption are indexed by rekey-notes
+ALTER TABLE targets ADD COLUMN IF NOT EXISTS notes_index TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS "targets_notes_index_idx" ON "targets" USING GIN ("notes_index");
+
+-- encrypted notes can't be indexed as text, the target itself is searched by name and its notes through their blind index
+DROP INDEX IF EXISTS "targets_search_idx";
+ALTER TABLE targets DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE targets ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple'::regconfig, name), 'A')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS "targets_search_idx" ON "targets" USING GIN ("search_vector");
@@ -0,0 +1,4 @@
+ALTER TABLE mission_schedule_runs DROP COLUMN IF EXISTS failed_at;
+ALTER TABLE mission_schedule_runs DROP COLUMN IF EXISTS last_error;
+ALTER TABLE mission_schedule_runs DROP COLUMN IF EXISTS attempts;
+ALTER TABLE mission_schedule_runs DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,7 @@
+-- a claim without a mission is taken over once claimed_at is stale, failed_at marks an occurrence given up after too many attempts
+ALTER TABLE mission_schedule_runs ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ DEFAULT now();
+ALTER TABLE mission_schedule_runs ADD COLUMN IF NOT EXISTS attempts INT NOT NULL DEFAULT 1;
+ALTER TABLE mission_schedule_runs ADD COLUMN IF NOT EXISTS last_error TEXT;
+ALTER TABLE mission_schedule_runs ADD COLUMN IF NOT EXISTS failed_at TIMESTAMPTZ;
+
+UPDATE mission_schedule_runs SET claimed_at = created_at;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "missions_occurrence_idx";
+
+ALTER TABLE missions DROP COLUMN IF EXISTS occurrence_at;
+ALTER TABLE missions DROP COLUMN IF EXISTS schedule_id;
@@ -0,0 +1,5 @@
+-- the occurrence a mission was materialized for, the unique key keeps a retried occurrence from creating a second mission
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS schedule_id UUID REFERENCES "mission_schedules" (id) ON DELETE SET NULL;
+ALTER TABLE missions ADD COLUMN IF NOT EXISTS occurrence_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "missions_occurrence_idx" ON "missions" ("schedule_id", "occurrence_at");
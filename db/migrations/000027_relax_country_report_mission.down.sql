@@ -0,0 +1,3 @@
+DELETE FROM target_country_backfill_report WHERE mission_id IS NULL;
+
+ALTER TABLE target_country_backfill_report ALTER COLUMN mission_id SET NOT NULL;
@@ -0,0 +1,2 @@
+-- targets without a mission have to be reportable too, later backfills run from Go with the embedded dataset
+ALTER TABLE target_country_backfill_report ALTER COLUMN mission_id DROP NOT NULL;
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/config"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	database "github.com/PureTeamLead/go-test-assessment-developstoday/pkg/storage/postgres"
+	"go.uber.org/zap"
+	"log"
+)
+
+// BackfillCountries normalizes the stored target countries with the embedded ISO 3166-1 dataset,
+// unmatched values are left for a manual fix in target_country_backfill_report. It can be run again at any time
+func BackfillCountries() {
+	ctx := context.Background()
+
+	configPath := flag.String("config", "./configs/prod-config.yaml", "Specifying the path of the config file")
+	batchSize := flag.Int("batch", 500, "Targets normalized per transaction")
+	flag.Parse()
+
+	if *batchSize <= 0 {
+		log.Fatalf("Batch size must be positive, got %d", *batchSize)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Config is not loaded: %s", err.Error())
+	}
+
+	ctx = logger.New(ctx, cfg.Env)
+
+	db, err := database.NewPostgres(ctx, cfg.DBConfig)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to set up database: " + err.Error())
+	}
+	defer db.Close()
+
+	// countries are stored in plain text, the notes keys are not needed
+	backfill, err := target.NewRepository(db, nil).BackfillCountries(ctx, *batchSize)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to backfill countries: "+err.Error(),
+			zap.Int("normalized", backfill.Normalized),
+			zap.Int("unmatched", backfill.Unmatched),
+		)
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info("Countries backfilled",
+		zap.Int("normalized", backfill.Normalized),
+		zap.Int("unmatched", backfill.Unmatched),
+	)
+}
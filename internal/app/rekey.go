@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/config"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	database "github.com/PureTeamLead/go-test-assessment-developstoday/pkg/storage/postgres"
+	"go.uber.org/zap"
+	"log"
+)
+
+// RekeyNotes re-encrypts stored target notes and their revisions with the active key after a rotation and
+// encrypts the ones written before encryption was enabled, the blind index of the notes is rebuilt on the way.
+// With -reindex every row is rewritten, with -decrypt the notes are written back in plain text instead
+func RekeyNotes() {
+	ctx := context.Background()
+
+	configPath := flag.String("config", "./configs/prod-config.yaml", "Specifying the path of the config file")
+	decrypt := flag.Bool("decrypt", false, "Store the notes in plain text again, needed before rolling the encryption migration back")
+	reindex := flag.Bool("reindex", false, "Rewrite every row, needed after the index key changed")
+	batchSize := flag.Int("batch", 500, "Rows re-encrypted per transaction")
+	flag.Parse()
+
+	if *batchSize <= 0 {
+		log.Fatalf("Batch size must be positive, got %d", *batchSize)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Config is not loaded: %s", err.Error())
+	}
+
+	ctx = logger.New(ctx, cfg.Env)
+
+	db, err := database.NewPostgres(ctx, cfg.DBConfig)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to set up database: " + err.Error())
+	}
+	defer db.Close()
+
+	keys, err := encryption.NewKeyring(cfg.Encryption)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to load notes encryption keys: " + err.Error())
+	}
+
+	opts := target.ReencryptOptions{Decrypt: *decrypt, Reindex: *reindex, BatchSize: *batchSize}
+
+	count, err := target.NewRepository(db, keys).ReencryptNotes(ctx, opts)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to re-encrypt notes: "+err.Error(), zap.Int("rewritten", count))
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info("Notes re-encrypted",
+		zap.Int("rewritten", count),
+		zap.String("active_key_id", keys.ActiveKeyID()),
+		zap.Bool("decrypt", *decrypt),
+		zap.Bool("reindex", *reindex),
+	)
+}
@@ -5,8 +5,16 @@ import (
 	"flag"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/config"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/dossier"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/expense"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/schedule"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/template"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/export"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/scheduler"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/handler"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
@@ -46,17 +54,38 @@ func Run() {
 	}
 	defer db.Close()
 
+	keys, err := encryption.NewKeyring(cfg.Encryption)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to load notes encryption keys: " + err.Error())
+	}
+
 	catRepo := cat.NewRepository(db)
 	missionRepo := mission.NewRepository(db)
-	targetRepo := target.NewRepository(db)
+	targetRepo := target.NewRepository(db, keys)
+	templateRepo := template.NewRepository(db)
+	expenseRepo := expense.NewRepository(db)
+	eventRepo := event.NewRepository(db)
+	scheduleRepo := schedule.NewRepository(db)
+	dossierRepo := dossier.NewRepository(db)
 
 	catSvc := cat.NewService(catRepo)
-	misTarSvc := service.New(missionRepo, targetRepo)
+	templateSvc := template.NewService(templateRepo)
+	misTarSvc := service.New(missionRepo, targetRepo, templateRepo, expenseRepo, eventRepo, scheduleRepo, dossierRepo, cfg.Missions)
+
+	exporter, err := export.New(cfg.Export)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Fatal("Failed to load export templates: " + err.Error())
+	}
 
 	logger.GetLoggerFromCtx(ctx).WithPort(ctx, portCtx)
-	transport := handler.New(ctx, cfg.HTTPSrvConfig, catSvc, misTarSvc)
+	transport := handler.New(ctx, cfg.HTTPSrvConfig, catSvc, misTarSvc, templateSvc, exporter)
 	transport.InitRoutes()
 
+	jobs := scheduler.New(ctx)
+	jobs.Every("overdue-missions", cfg.Scheduler.OverdueInterval, scheduler.OverdueJob(misTarSvc, scheduler.LogNotifier{}))
+	jobs.Every("mission-schedules", cfg.Scheduler.ScheduleInterval, scheduler.SchedulesJob(misTarSvc))
+	jobs.Every("purge-trash", cfg.Scheduler.PurgeInterval, scheduler.PurgeTrashJob(misTarSvc))
+
 	go func() {
 		if err = transport.Run(); err != nil {
 			logger.GetLoggerFromCtx(ctx).Error("HTTP server stopped", err)
@@ -75,4 +104,6 @@ func Run() {
 	if err = transport.Stop(ctx); err != nil {
 		logger.GetLoggerFromCtx(ctx).Error("failed server shutdown", err)
 	}
+
+	jobs.Stop()
 }
@@ -2,6 +2,10 @@ package config
 
 import (
 	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/export"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/scheduler"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/server"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/storage/postgres"
 	"github.com/ilyakaznacheev/cleanenv"
@@ -17,6 +21,10 @@ type AppConfig struct {
 	Env           string                  `yaml:"env" env:"ENVIRONMENT"`
 	DBConfig      database.PostgresConfig `yaml:"db"`
 	HTTPSrvConfig server.Config           `yaml:"http-server"`
+	Scheduler     scheduler.Config        `yaml:"scheduler"`
+	Missions      service.Config          `yaml:"missions"`
+	Export        export.Config           `yaml:"export"`
+	Encryption    encryption.Config       `yaml:"encryption"`
 }
 
 func Load(path string) (*AppConfig, error) {
@@ -0,0 +1,108 @@
+package country
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+)
+
+//go:embed iso3166.json
+var rawCountries []byte
+
+// Country is an ISO 3166-1 entry, Aliases hold common spellings that are not part of the standard
+type Country struct {
+	Alpha2  string   `json:"alpha2"`
+	Alpha3  string   `json:"alpha3"`
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+var (
+	countries = loadCountries()
+	byAlpha2  = indexAlpha2(countries)
+	byKey     = indexKeys(countries)
+)
+
+func loadCountries() []*Country {
+	var list []*Country
+	if err := json.Unmarshal(rawCountries, &list); err != nil {
+		panic("country: malformed ISO 3166 dataset: " + err.Error())
+	}
+
+	return list
+}
+
+func indexAlpha2(list []*Country) map[string]*Country {
+	index := make(map[string]*Country, len(list))
+	for _, c := range list {
+		index[c.Alpha2] = c
+	}
+
+	return index
+}
+
+// indexKeys codes win over names, so a name can never shadow another country's code
+func indexKeys(list []*Country) map[string]*Country {
+	index := make(map[string]*Country, 4*len(list))
+	for _, c := range list {
+		for _, alias := range c.Aliases {
+			index[normalizeKey(alias)] = c
+		}
+		index[normalizeKey(c.Name)] = c
+	}
+
+	for _, c := range list {
+		index[c.Alpha3] = c
+	}
+
+	for _, c := range list {
+		index[c.Alpha2] = c
+	}
+
+	return index
+}
+
+// normalizeKey makes "U.S.", "u s" and "US" the same key, the first backfill migration applies the same rules in SQL
+func normalizeKey(value string) string {
+	value = strings.ToUpper(value)
+	value = strings.ReplaceAll(value, ".", "")
+	value = strings.ReplaceAll(value, "&", " AND ")
+	value = strings.Join(strings.Fields(value), " ")
+
+	return strings.TrimPrefix(value, "THE ")
+}
+
+// Lookup accepts an alpha-2 or alpha-3 code, the english name or one of the aliases, case insensitive
+func Lookup(value string) (*Country, bool) {
+	c, ok := byKey[normalizeKey(value)]
+	if !ok {
+		// "U S" is a spaced code rather than a name
+		c, ok = byKey[strings.ReplaceAll(normalizeKey(value), " ", "")]
+	}
+
+	return c, ok
+}
+
+// Code returns the alpha-2 code of the country, unknown values are returned unchanged
+func Code(value string) string {
+	if c, ok := Lookup(value); ok {
+		return c.Alpha2
+	}
+
+	return value
+}
+
+// IsCode reports whether the value is an alpha-2 code exactly as it is stored
+func IsCode(value string) bool {
+	_, ok := byAlpha2[value]
+	return ok
+}
+
+// DisplayName returns the english name for the alpha-2 code, values stored before normalization are returned as is
+func DisplayName(code string) string {
+	if c, ok := byAlpha2[code]; ok {
+		return c.Name
+	}
+
+	return code
+}
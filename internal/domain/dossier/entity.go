@@ -0,0 +1,80 @@
+package dossier
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	// StatusUntracked no target is linked to the dossier yet
+	StatusUntracked = "untracked"
+	// StatusActive at least one linked target is still pursued by a running mission
+	StatusActive = "active"
+	// StatusClosed at least one mission has completed its linked target
+	StatusClosed = "closed"
+	// StatusDormant every linked target was abandoned or its mission aborted
+	StatusDormant = "dormant"
+)
+
+// Dossier is a person known across missions, Country is an ISO 3166-1 alpha-2 code like on targets
+type Dossier struct {
+	ID          uuid.UUID
+	Name        string `validate:"required,max=100"`
+	Country     string `validate:"required"`
+	CountryName string
+	Aliases     []string `validate:"max=20,dive,required,max=100"`
+	Description string   `validate:"max=4000"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CreateDossierSvc struct {
+	Name        string
+	Country     string
+	Aliases     []string
+	Description string
+}
+
+// NewEntity : aliases repeating the name or each other are dropped
+func NewEntity(req CreateDossierSvc) *Dossier {
+	code := country.Code(strings.TrimSpace(req.Country))
+	name := strings.TrimSpace(req.Name)
+
+	seen := map[string]bool{strings.ToLower(name): true}
+	aliases := make([]string, 0, len(req.Aliases))
+	for _, alias := range req.Aliases {
+		alias = strings.TrimSpace(alias)
+		if seen[strings.ToLower(alias)] {
+			continue
+		}
+
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+
+	return &Dossier{
+		Name:        name,
+		Country:     code,
+		CountryName: country.DisplayName(code),
+		Aliases:     aliases,
+		Description: req.Description,
+	}
+}
+
+func (d *Dossier) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(d); err != nil {
+		return utils.ErrInvalidDossier
+	}
+
+	if !country.IsCode(d.Country) {
+		return utils.ErrUnknownCountry
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package dossier
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MinMatchScore suggestions scoring below are not worth showing
+	MinMatchScore = 0.5
+
+	nameWeight    = 0.75
+	countryWeight = 0.25
+)
+
+type Suggestion struct {
+	Dossier *Dossier
+	Score   float64
+}
+
+// Score rates how likely a target with the given name and country is the dossier subject, from 0 to 1.
+// Names are compared by their words, the best of the dossier name and aliases counts
+func (d *Dossier) Score(name, countryCode string) float64 {
+	words := nameWords(name)
+
+	best := wordOverlap(words, nameWords(d.Name))
+	for _, alias := range d.Aliases {
+		if overlap := wordOverlap(words, nameWords(alias)); overlap > best {
+			best = overlap
+		}
+	}
+
+	score := nameWeight * best
+	if strings.EqualFold(d.Country, countryCode) {
+		score += countryWeight
+	}
+
+	return score
+}
+
+// Suggest returns the dossiers scoring at least MinMatchScore, the most likely first
+func Suggest(dossiers []*Dossier, name, countryCode string, limit int) []*Suggestion {
+	suggestions := make([]*Suggestion, 0)
+	for _, d := range dossiers {
+		if score := d.Score(name, countryCode); score >= MinMatchScore {
+			suggestions = append(suggestions, &Suggestion{Dossier: d, Score: score})
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+
+	return suggestions
+}
+
+func nameWords(name string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+
+	return words
+}
+
+// wordOverlap is the Jaccard index of the two word sets
+func wordOverlap(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for word := range a {
+		if b[word] {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(a)+len(b)-common)
+}
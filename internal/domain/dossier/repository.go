@@ -0,0 +1,177 @@
+package dossier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	db      *pgxpool.Pool
+	builder sq.StatementBuilderType
+}
+
+const (
+	tableName         = "dossiers"
+	idColumn          = "id"
+	nameColumn        = "name"
+	countryColumn     = "country"
+	aliasesColumn     = "aliases"
+	descriptionColumn = "description"
+	createdAtColumn   = "created_at"
+	updatedAtColumn   = "updated_at"
+)
+
+var selectColumns = []string{
+	idColumn,
+	nameColumn,
+	countryColumn,
+	aliasesColumn,
+	descriptionColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	builder := sq.StatementBuilderType{}
+	builder = builder.PlaceholderFormat(sq.Dollar)
+	return &Repository{db: pool, builder: builder}
+}
+
+func scanDossier(row pgx.Row, d *Dossier) error {
+	var rawAliases []byte
+
+	err := row.Scan(
+		&d.ID,
+		&d.Name,
+		&d.Country,
+		&rawAliases,
+		&d.Description,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	d.CountryName = country.DisplayName(d.Country)
+
+	return json.Unmarshal(rawAliases, &d.Aliases)
+}
+
+func (r *Repository) AddDossier(ctx context.Context, d *Dossier) (uuid.UUID, error) {
+	const op = "dossier.Repository.AddDossier"
+	var id uuid.UUID
+
+	rawAliases, err := json.Marshal(d.Aliases)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := r.builder.
+		Insert(tableName).
+		Columns(nameColumn, countryColumn, aliasesColumn, descriptionColumn).
+		Values(d.Name, d.Country, rawAliases, d.Description).
+		Suffix("RETURNING " + idColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetDossierByID(ctx context.Context, id uuid.UUID) (*Dossier, error) {
+	const op = "dossier.Repository.GetDossierByID"
+	var d Dossier
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = scanDossier(r.db.QueryRow(ctx, query, args...), &d); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrDossierNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &d, nil
+}
+
+func (r *Repository) GetDossiers(ctx context.Context) ([]*Dossier, error) {
+	const op = "dossier.Repository.GetDossiers"
+
+	return r.queryDossiers(ctx, op, nil)
+}
+
+// GetMatchCandidates narrows the dossiers worth scoring for a target: those from the same country,
+// and those elsewhere whose name or one of the aliases is exactly the target name
+func (r *Repository) GetMatchCandidates(ctx context.Context, name, countryCode string) ([]*Dossier, error) {
+	const op = "dossier.Repository.GetMatchCandidates"
+
+	return r.queryDossiers(ctx, op, sq.Or{
+		sq.Eq{countryColumn: countryCode},
+		sq.Expr("lower("+nameColumn+") = lower(?)", name),
+		sq.Expr("EXISTS (SELECT 1 FROM jsonb_array_elements_text("+aliasesColumn+") alias WHERE lower(alias) = lower(?))", name),
+	})
+}
+
+func (r *Repository) queryDossiers(ctx context.Context, op string, pred sq.Sqlizer) ([]*Dossier, error) {
+	dossiers := make([]*Dossier, 0)
+
+	builder := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		OrderBy(nameColumn, idColumn)
+
+	if pred != nil {
+		builder = builder.Where(pred)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d Dossier
+
+		if err = scanDossier(rows, &d); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		dossiers = append(dossiers, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dossiers, nil
+}
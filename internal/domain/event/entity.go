@@ -0,0 +1,84 @@
+package event
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	MissionCreated       = "mission_created"
+	MissionUpdated       = "mission_updated"
+	MissionCompleted     = "mission_completed"
+	MissionAborted       = "mission_aborted"
+	MissionOverdue       = "mission_overdue"
+	MissionDeleted       = "mission_deleted"
+	MissionRestored      = "mission_restored"
+	CatAssigned          = "cat_assigned"
+	TargetAdded          = "target_added"
+	TargetRemoved        = "target_removed"
+	TargetCompleted      = "target_completed"
+	TargetMoved          = "target_moved"
+	TargetStateChanged   = "target_state_changed"
+	NotesUpdated         = "notes_updated"
+	NotesReverted        = "notes_reverted"
+	NotesSummarized      = "notes_summarized"
+	JournalEntryAdded    = "journal_entry_added"
+	JournalEntryEdited   = "journal_entry_edited"
+	SightingAdded        = "sighting_added"
+	DossierLinked        = "dossier_linked"
+	DossierUnlinked      = "dossier_unlinked"
+	DependencyAdded      = "dependency_added"
+	DependencyRemoved    = "dependency_removed"
+	DependencyFailed     = "dependency_failed"
+	ExpenseSubmitted     = "expense_submitted"
+	ExpenseStatusChanged = "expense_status_changed"
+	ApprovalDecided      = "approval_decided"
+	ApprovalRechecked    = "approval_rechecked"
+
+	// SystemActor is recorded for changes made without a caller, e.g. by scheduled jobs
+	SystemActor = "system"
+)
+
+type Event struct {
+	ID        uuid.UUID
+	MissionID uuid.UUID
+	Type      string
+	Actor     string
+	Payload   map[string]interface{}
+	CreatedAt time.Time
+}
+
+// Page NextCursor is empty on the last page
+type Page struct {
+	Events     []*Event
+	NextCursor string
+}
+
+type actorKey struct{}
+
+func NewEntity(missionID uuid.UUID, eventType, actor string, payload map[string]interface{}) *Event {
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+
+	return &Event{
+		MissionID: missionID,
+		Type:      eventType,
+		Actor:     actor,
+		Payload:   payload,
+	}
+}
+
+// WithActor stores who is performing the operation, events recorded with the returned context are attributed to them
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromCtx(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+
+	return SystemActor
+}
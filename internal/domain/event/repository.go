@@ -0,0 +1,178 @@
+package event
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	db      *pgxpool.Pool
+	builder sq.StatementBuilderType
+}
+
+const (
+	tableName       = "mission_events"
+	idColumn        = "id"
+	missionIDColumn = "mission_id"
+	typeColumn      = "event_type"
+	actorColumn     = "actor"
+	payloadColumn   = "payload"
+	createdAtColumn = "created_at"
+)
+
+var selectColumns = []string{
+	idColumn,
+	missionIDColumn,
+	typeColumn,
+	actorColumn,
+	payloadColumn,
+	createdAtColumn,
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	builder := sq.StatementBuilderType{}
+	builder = builder.PlaceholderFormat(sq.Dollar)
+	return &Repository{db: pool, builder: builder}
+}
+
+func (r *Repository) AddEvent(ctx context.Context, event *Event) error {
+	const op = "event.Repository.AddEvent"
+
+	query, args, err := r.insertQuery(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddEventTx records the event as part of the caller's transaction, it is rolled back together with the change
+func (r *Repository) AddEventTx(ctx context.Context, tx pgx.Tx, event *Event) error {
+	const op = "event.Repository.AddEventTx"
+
+	query, args, err := r.insertQuery(event)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) insertQuery(event *Event) (string, []interface{}, error) {
+	return r.builder.
+		Insert(tableName).
+		Columns(missionIDColumn, typeColumn, actorColumn, payloadColumn).
+		Values(event.MissionID, event.Type, event.Actor, event.Payload).
+		ToSql()
+}
+
+// GetMissionEvents returns the events of the mission oldest first, starting after the cursor if one is given
+func (r *Repository) GetMissionEvents(ctx context.Context, missionID uuid.UUID, cursor *mission.Cursor, limit int) (*Page, error) {
+	const op = "event.Repository.GetMissionEvents"
+
+	builder := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{missionIDColumn: missionID}).
+		OrderBy(createdAtColumn, idColumn).
+		Limit(uint64(limit) + 1)
+
+	if cursor != nil {
+		builder = builder.Where(
+			sq.Expr(fmt.Sprintf("(%s, %s) > (?, ?)", createdAtColumn, idColumn), cursor.SortValue, cursor.ID),
+		)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	page := &Page{Events: make([]*Event, 0, limit)}
+	for rows.Next() {
+		var event Event
+
+		err = rows.Scan(&event.ID, &event.MissionID, &event.Type, &event.Actor, &event.Payload, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		page.Events = append(page.Events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// one extra row was requested to learn whether another page follows
+	if len(page.Events) > limit {
+		page.Events = page.Events[:limit]
+		last := page.Events[limit-1]
+		page.NextCursor = mission.Cursor{SortValue: last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// GetEventsByMissionIDs returns the events of all given missions in one list, oldest first
+func (r *Repository) GetEventsByMissionIDs(ctx context.Context, missionIDs []uuid.UUID) ([]*Event, error) {
+	const op = "event.Repository.GetEventsByMissionIDs"
+	events := make([]*Event, 0)
+
+	if len(missionIDs) == 0 {
+		return events, nil
+	}
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Expr(missionIDColumn+" = ANY(?)", missionIDs)).
+		OrderBy(createdAtColumn, idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event Event
+
+		err = rows.Scan(&event.ID, &event.MissionID, &event.Type, &event.Actor, &event.Payload, &event.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return events, nil
+}
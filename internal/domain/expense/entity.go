@@ -0,0 +1,96 @@
+package expense
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	StatusSubmitted  = "submitted"
+	StatusApproved   = "approved"
+	StatusRejected   = "rejected"
+	StatusReimbursed = "reimbursed"
+)
+
+// CountedStatuses claims in these statuses are part of mission cost and payroll
+var CountedStatuses = []string{StatusApproved, StatusReimbursed}
+
+// transitions claims move submitted -> approved/rejected -> reimbursed, rejected and reimbursed are final
+var transitions = map[string][]string{
+	StatusSubmitted: {StatusApproved, StatusRejected},
+	StatusApproved:  {StatusReimbursed},
+}
+
+type Expense struct {
+	ID           uuid.UUID
+	MissionID    uuid.UUID
+	CatID        *uuid.UUID
+	AmountCents  int64  `validate:"gt=0"`
+	Currency     string `validate:"iso4217"`
+	Category     string `validate:"oneof=travel lodging food equipment other"`
+	Description  string `validate:"max=500"`
+	Status       string
+	ReceiptPath  *string
+	ReviewNote   string
+	ReviewedAt   *time.Time
+	ReimbursedAt *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewEntity : the claim always starts as submitted, receipt is attached by the caller
+func NewEntity(missionID uuid.UUID, catID *uuid.UUID, amountCents int64, currency, category, description string) *Expense {
+	return &Expense{
+		MissionID:   missionID,
+		CatID:       catID,
+		AmountCents: amountCents,
+		Currency:    strings.ToUpper(currency),
+		Category:    strings.ToLower(category),
+		Description: description,
+		Status:      StatusSubmitted,
+	}
+}
+
+func (e *Expense) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(e); err != nil {
+		return utils.ErrInvalidExpense
+	}
+
+	return nil
+}
+
+// Transition moves the claim to the given status, stamping the review or reimbursement time
+func (e *Expense) Transition(status, note string, now time.Time) error {
+	allowed := false
+	for _, next := range transitions[e.Status] {
+		if next == status {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return utils.ErrExpenseTransition
+	}
+
+	switch status {
+	case StatusApproved, StatusRejected:
+		e.ReviewNote = note
+		e.ReviewedAt = &now
+	case StatusReimbursed:
+		e.ReimbursedAt = &now
+	}
+
+	e.Status = status
+	return nil
+}
+
+// Counted reports whether the claim contributes to mission cost and payroll
+func (e *Expense) Counted() bool {
+	return e.Status == StatusApproved || e.Status == StatusReimbursed
+}
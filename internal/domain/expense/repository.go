@@ -0,0 +1,305 @@
+package expense
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type Repository struct {
+	db      *pgxpool.Pool
+	builder sq.StatementBuilderType
+}
+
+const (
+	tableName          = "mission_expenses"
+	idColumn           = "id"
+	missionIDColumn    = "mission_id"
+	catIDColumn        = "cat_id"
+	amountColumn       = "amount_cents"
+	currencyColumn     = "currency"
+	categoryColumn     = "category"
+	descriptionColumn  = "description"
+	statusColumn       = "status"
+	receiptPathColumn  = "receipt_path"
+	reviewNoteColumn   = "review_note"
+	reviewedAtColumn   = "reviewed_at"
+	reimbursedAtColumn = "reimbursed_at"
+	createdAtColumn    = "created_at"
+	updatedAtColumn    = "updated_at"
+)
+
+var selectColumns = []string{
+	idColumn,
+	missionIDColumn,
+	catIDColumn,
+	amountColumn,
+	currencyColumn,
+	categoryColumn,
+	descriptionColumn,
+	statusColumn,
+	receiptPathColumn,
+	reviewNoteColumn,
+	reviewedAtColumn,
+	reimbursedAtColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	builder := sq.StatementBuilderType{}
+	builder = builder.PlaceholderFormat(sq.Dollar)
+	return &Repository{db: pool, builder: builder}
+}
+
+func scanExpense(row pgx.Row, expense *Expense) error {
+	return row.Scan(
+		&expense.ID,
+		&expense.MissionID,
+		&expense.CatID,
+		&expense.AmountCents,
+		&expense.Currency,
+		&expense.Category,
+		&expense.Description,
+		&expense.Status,
+		&expense.ReceiptPath,
+		&expense.ReviewNote,
+		&expense.ReviewedAt,
+		&expense.ReimbursedAt,
+		&expense.CreatedAt,
+		&expense.UpdatedAt,
+	)
+}
+
+func (r *Repository) AddExpense(ctx context.Context, expense *Expense) (uuid.UUID, error) {
+	const op = "expense.Repository.AddExpense"
+	var id uuid.UUID
+
+	query, args, err := r.builder.
+		Insert(tableName).
+		Columns(
+			missionIDColumn,
+			catIDColumn,
+			amountColumn,
+			currencyColumn,
+			categoryColumn,
+			descriptionColumn,
+			statusColumn,
+			receiptPathColumn,
+		).
+		Values(
+			expense.MissionID,
+			expense.CatID,
+			expense.AmountCents,
+			expense.Currency,
+			expense.Category,
+			expense.Description,
+			expense.Status,
+			expense.ReceiptPath,
+		).
+		Suffix("RETURNING " + idColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.db.QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23503" {
+				return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+			}
+		}
+
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetExpenseByID(ctx context.Context, missionID, id uuid.UUID) (*Expense, error) {
+	const op = "expense.Repository.GetExpenseByID"
+	var expense Expense
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{idColumn: id, missionIDColumn: missionID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = scanExpense(r.db.QueryRow(ctx, query, args...), &expense); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrExpenseNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &expense, nil
+}
+
+// UpdateExpenseStatus applies the change only if the stored status is still fromStatus,
+// so two reviewers racing on the same claim can't both succeed
+func (r *Repository) UpdateExpenseStatus(ctx context.Context, expense *Expense, fromStatus string) error {
+	const op = "expense.Repository.UpdateExpenseStatus"
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(statusColumn, expense.Status).
+		Set(reviewNoteColumn, expense.ReviewNote).
+		Set(reviewedAtColumn, expense.ReviewedAt).
+		Set(reimbursedAtColumn, expense.ReimbursedAt).
+		Where(sq.Eq{idColumn: expense.ID, statusColumn: fromStatus}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrExpenseTransition)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetExpensesByMissionID(ctx context.Context, missionID uuid.UUID) ([]*Expense, error) {
+	const op = "expense.Repository.GetExpensesByMissionID"
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{missionIDColumn: missionID}).
+		OrderBy(createdAtColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expenses, err := r.queryExpenses(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expenses, nil
+}
+
+// GetExpensesBetween lists expenses of all missions in the given statuses submitted within [from, to)
+func (r *Repository) GetExpensesBetween(ctx context.Context, from, to time.Time, statuses []string) ([]*Expense, error) {
+	const op = "expense.Repository.GetExpensesBetween"
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.GtOrEq{createdAtColumn: from}).
+		Where(sq.Lt{createdAtColumn: to}).
+		Where(sq.Eq{statusColumn: statuses}).
+		OrderBy(createdAtColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expenses, err := r.queryExpenses(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expenses, nil
+}
+
+// SumByMissionIDs totals the expenses in the given statuses per mission and currency,
+// missions without such expenses are absent from the result
+func (r *Repository) SumByMissionIDs(ctx context.Context, missionIDs []uuid.UUID, statuses []string) (map[uuid.UUID]map[string]int64, error) {
+	const op = "expense.Repository.SumByMissionIDs"
+	sums := make(map[uuid.UUID]map[string]int64, len(missionIDs))
+
+	if len(missionIDs) == 0 {
+		return sums, nil
+	}
+
+	query, args, err := r.builder.
+		Select(missionIDColumn, currencyColumn, "SUM("+amountColumn+")::BIGINT").
+		From(tableName).
+		Where(sq.Expr(missionIDColumn+" = ANY(?)", missionIDs)).
+		Where(sq.Eq{statusColumn: statuses}).
+		GroupBy(missionIDColumn, currencyColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var missionID uuid.UUID
+		var currency string
+		var sum int64
+
+		if err = rows.Scan(&missionID, &currency, &sum); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if sums[missionID] == nil {
+			sums[missionID] = make(map[string]int64)
+		}
+		sums[missionID][currency] = sum
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sums, nil
+}
+
+func (r *Repository) queryExpenses(ctx context.Context, query string, args ...interface{}) ([]*Expense, error) {
+	expenses := make([]*Expense, 0)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var expense Expense
+
+		if err = scanExpense(rows, &expense); err != nil {
+			return nil, err
+		}
+
+		expenses = append(expenses, &expense)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
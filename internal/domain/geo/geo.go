@@ -0,0 +1,68 @@
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"math"
+	"strings"
+)
+
+// bboxMargin degrees added around every box, the dataset is coarse and sightings near a border must pass
+const bboxMargin = 0.5
+
+//go:embed bboxes.json
+var rawBoxes []byte
+
+// box is [min longitude, min latitude, max longitude, max latitude],
+// min longitude above max longitude means the box crosses the antimeridian
+type box struct {
+	Code string     `json:"code"`
+	Name string     `json:"name"`
+	BBox [4]float64 `json:"bbox"`
+}
+
+var boxes = loadBoxes()
+
+func loadBoxes() map[string]box {
+	var list []box
+	if err := json.Unmarshal(rawBoxes, &list); err != nil {
+		panic("geo: malformed bounding box dataset: " + err.Error())
+	}
+
+	byKey := make(map[string]box, 2*len(list))
+	for _, b := range list {
+		byKey[strings.ToUpper(b.Code)] = b
+		byKey[strings.ToUpper(b.Name)] = b
+	}
+
+	return byKey
+}
+
+func ValidateCoordinates(lat, lng float64) error {
+	if math.IsNaN(lat) || math.IsNaN(lng) || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return utils.ErrInvalidCoordinates
+	}
+
+	return nil
+}
+
+// InCountry reports whether the point lies in the bounding box of the country, given as an alpha-2 code or
+// an english name. known is false for countries missing from the dataset, those can not be checked
+func InCountry(country string, lat, lng float64) (inside bool, known bool) {
+	b, ok := boxes[strings.ToUpper(strings.TrimSpace(country))]
+	if !ok {
+		return false, false
+	}
+
+	minLng, minLat, maxLng, maxLat := b.BBox[0], b.BBox[1], b.BBox[2], b.BBox[3]
+	if lat < minLat-bboxMargin || lat > maxLat+bboxMargin {
+		return false, true
+	}
+
+	if minLng <= maxLng {
+		return lng >= minLng-bboxMargin && lng <= maxLng+bboxMargin, true
+	}
+
+	return lng >= minLng-bboxMargin || lng <= maxLng+bboxMargin, true
+}
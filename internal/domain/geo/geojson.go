@@ -0,0 +1,31 @@
+package geo
+
+// FeatureCollection is the GeoJSON (RFC 7946) document, only point features are produced
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   Geometry               `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{Type: "FeatureCollection", Features: make([]*Feature, 0)}
+}
+
+// AddPoint : GeoJSON positions are longitude first
+func (fc *FeatureCollection) AddPoint(lat, lng float64, properties map[string]interface{}) {
+	fc.Features = append(fc.Features, &Feature{
+		Type:       "Feature",
+		Geometry:   Geometry{Type: "Point", Coordinates: []float64{lng, lat}},
+		Properties: properties,
+	})
+}
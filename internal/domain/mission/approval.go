@@ -0,0 +1,304 @@
+package mission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"time"
+)
+
+const (
+	ApprovalPending  = "pending_approval"
+	ApprovalApproved = "approved"
+	ApprovalRejected = "rejected"
+
+	approvalsTableName      = "mission_approvals"
+	approvalMissionIDColumn = "mission_id"
+	approvalApproverColumn  = "approver"
+	approvalDecisionColumn  = "decision"
+	approvalCommentColumn   = "comment"
+	approvalCreatedAtColumn = "created_at"
+	approvalStatusColumn    = "approval_status"
+)
+
+// Approval is a single supervisor decision, Decision is either ApprovalApproved or ApprovalRejected
+type Approval struct {
+	MissionID uuid.UUID
+	Approver  string
+	Decision  string
+	Comment   string
+	CreatedAt time.Time
+}
+
+// DecideApproval stores the decision and settles the mission approval status: a single rejection rejects
+// the mission, it is approved once it has collected the required number of approvals.
+// The mission row is locked so concurrent decisions are counted one after another
+func (r *Repository) DecideApproval(ctx context.Context, approval *Approval, required int) (string, error) {
+	const op = "mission.Repository.DecideApproval"
+	var status string
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.builder.
+		Select(approvalStatusColumn).
+		From(tableName).
+		Where(sq.Eq{idColumn: approval.MissionID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if status != ApprovalPending {
+		return "", fmt.Errorf("%s: %w", op, utils.ErrApprovalClosed)
+	}
+
+	query, args, err = r.builder.
+		Insert(approvalsTableName).
+		Columns(approvalMissionIDColumn, approvalApproverColumn, approvalDecisionColumn, approvalCommentColumn).
+		Values(approval.MissionID, approval.Approver, approval.Decision, approval.Comment).
+		ToSql()
+
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return "", fmt.Errorf("%s: %w", op, utils.ErrAlreadyDecided)
+			}
+		}
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if approval.Decision == ApprovalRejected {
+		status = ApprovalRejected
+	} else {
+		approvals, err := r.countApprovals(ctx, tx, approval.MissionID)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		if approvals >= required {
+			status = ApprovalApproved
+		}
+	}
+
+	if status != ApprovalPending {
+		query, args, err = r.builder.
+			Update(tableName).
+			Set(approvalStatusColumn, status).
+			Where(sq.Eq{idColumn: approval.MissionID}).
+			ToSql()
+
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return "", fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return status, nil
+}
+
+// RecheckApproval settles the approval status again after the targets of the mission changed: an approved mission
+// goes back to pending once it needs more approvals than it has collected, a pending one is approved once it has
+// enough of them. Rejections are final. It reports whether the status changed
+func (r *Repository) RecheckApproval(ctx context.Context, id uuid.UUID, required int) (string, bool, error) {
+	const op = "mission.Repository.RecheckApproval"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	status, changed, err := r.RecheckApprovalTx(ctx, tx, id, required)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return status, changed, nil
+}
+
+// RecheckApprovalTx is RecheckApproval as part of the caller's transaction
+func (r *Repository) RecheckApprovalTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, required int) (string, bool, error) {
+	const op = "mission.Repository.RecheckApprovalTx"
+	var status string
+
+	query, args, err := r.builder.
+		Select(approvalStatusColumn).
+		From(tableName).
+		Where(sq.Eq{idColumn: id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&status); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", false, fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
+
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if status == ApprovalRejected {
+		return status, false, nil
+	}
+
+	approvals, err := r.countApprovals(ctx, tx, id)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	newStatus := ApprovalPending
+	if approvals >= required {
+		newStatus = ApprovalApproved
+	}
+
+	if newStatus == status {
+		return status, false, nil
+	}
+
+	query, args, err = r.builder.
+		Update(tableName).
+		Set(approvalStatusColumn, newStatus).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return "", false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return newStatus, true, nil
+}
+
+// countApprovals counts the approving decisions of the mission
+func (r *Repository) countApprovals(ctx context.Context, tx pgx.Tx, missionID uuid.UUID) (int, error) {
+	var approvals int
+
+	query, args, err := r.builder.
+		Select("COUNT(*)").
+		From(approvalsTableName).
+		Where(sq.Eq{approvalMissionIDColumn: missionID, approvalDecisionColumn: ApprovalApproved}).
+		ToSql()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&approvals); err != nil {
+		return 0, err
+	}
+
+	return approvals, nil
+}
+
+// SetApprovalStatus settles the approval status directly, used for missions which need no approvals
+func (r *Repository) SetApprovalStatus(ctx context.Context, id uuid.UUID, status string) error {
+	const op = "mission.Repository.SetApprovalStatus"
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(approvalStatusColumn, status).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetApprovals(ctx context.Context, missionID uuid.UUID) ([]*Approval, error) {
+	const op = "mission.Repository.GetApprovals"
+	approvals := make([]*Approval, 0)
+
+	query, args, err := r.builder.
+		Select(
+			approvalMissionIDColumn,
+			approvalApproverColumn,
+			approvalDecisionColumn,
+			approvalCommentColumn,
+			approvalCreatedAtColumn,
+		).
+		From(approvalsTableName).
+		Where(sq.Eq{approvalMissionIDColumn: missionID}).
+		OrderBy(approvalCreatedAtColumn, approvalApproverColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var approval Approval
+
+		err = rows.Scan(&approval.MissionID, &approval.Approver, &approval.Decision, &approval.Comment, &approval.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		approvals = append(approvals, &approval)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return approvals, nil
+}
@@ -0,0 +1,158 @@
+package mission
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	dependenciesTableName   = "mission_dependencies"
+	depMissionIDColumn      = "mission_id"
+	depPrerequisiteIDColumn = "prerequisite_id"
+	depAlias                = "d"
+)
+
+// reachesQuery walks the prerequisites of $1 transitively and reports whether $2 is among them
+const reachesQuery = `WITH RECURSIVE reach AS (
+		SELECT prerequisite_id FROM mission_dependencies WHERE mission_id = $1
+		UNION
+		SELECT d.prerequisite_id FROM mission_dependencies d JOIN reach r ON d.mission_id = r.prerequisite_id
+	)
+	SELECT EXISTS (SELECT 1 FROM reach WHERE prerequisite_id = $2)`
+
+// AddDependency makes missionID wait for prerequisiteID, adding an existing dependency is a no-op.
+// The table is locked for writes so two concurrent additions can't close a cycle together
+func (r *Repository) AddDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error {
+	const op = "mission.Repository.AddDependency"
+
+	if missionID == prerequisiteID {
+		return fmt.Errorf("%s: %w", op, utils.ErrDependencyCycle)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, "LOCK TABLE "+dependenciesTableName+" IN SHARE ROW EXCLUSIVE MODE"); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var cycle bool
+	if err = tx.QueryRow(ctx, reachesQuery, prerequisiteID, missionID).Scan(&cycle); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if cycle {
+		return fmt.Errorf("%s: %w", op, utils.ErrDependencyCycle)
+	}
+
+	query, args, err := r.builder.
+		Insert(dependenciesTableName).
+		Columns(depMissionIDColumn, depPrerequisiteIDColumn).
+		Values(missionID, prerequisiteID).
+		Suffix("ON CONFLICT DO NOTHING").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *Repository) RemoveDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error {
+	const op = "mission.Repository.RemoveDependency"
+
+	query, args, err := r.builder.
+		Delete(dependenciesTableName).
+		Where(sq.Eq{depMissionIDColumn: missionID, depPrerequisiteIDColumn: prerequisiteID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrDependencyNotFound)
+	}
+
+	return nil
+}
+
+// GetOpenPrerequisites lists the prerequisites of the mission that are not completed yet, trashed ones included
+// since they can still be restored
+func (r *Repository) GetOpenPrerequisites(ctx context.Context, missionID uuid.UUID) ([]*Mission, error) {
+	const op = "mission.Repository.GetOpenPrerequisites"
+
+	query, args, err := r.builder.
+		Select(qualified(missionAlias, selectColumns)...).
+		From(tableName + " " + missionAlias).
+		Join(fmt.Sprintf("%s %s ON %s.%s = %s.%s",
+			dependenciesTableName, depAlias, depAlias, depPrerequisiteIDColumn, missionAlias, idColumn)).
+		Where(sq.Eq{depAlias + "." + depMissionIDColumn: missionID}).
+		Where(sq.NotEq{missionAlias + "." + stateColumn: completedState}).
+		OrderBy(missionAlias + "." + createdAtColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	missions, err := r.queryMissions(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return missions, nil
+}
+
+// GetDependents lists the missions waiting for the given one
+func (r *Repository) GetDependents(ctx context.Context, prerequisiteID uuid.UUID) ([]*Mission, error) {
+	const op = "mission.Repository.GetDependents"
+
+	query, args, err := r.builder.
+		Select(qualified(missionAlias, selectColumns)...).
+		From(tableName + " " + missionAlias).
+		Join(fmt.Sprintf("%s %s ON %s.%s = %s.%s",
+			dependenciesTableName, depAlias, depAlias, depMissionIDColumn, missionAlias, idColumn)).
+		Where(sq.Eq{depAlias + "." + depPrerequisiteIDColumn: prerequisiteID}).
+		Where(sq.Eq{missionAlias + "." + deletedAtColumn: nil}).
+		OrderBy(missionAlias + "." + createdAtColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	missions, err := r.queryMissions(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return missions, nil
+}
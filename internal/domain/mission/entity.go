@@ -1,20 +1,145 @@
 package mission
 
 import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/google/uuid"
 	"time"
 )
 
 const MissionSize = 3
 
+// AbortReasons are the accepted reason codes of an aborted mission
+var AbortReasons = []string{"compromised", "target_lost", "cat_injured", "intel_invalid", "cancelled", "other"}
+
 type Mission struct {
-	ID        uuid.UUID
-	State     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             uuid.UUID
+	CatID          *uuid.UUID
+	Type           string
+	State          string
+	Deadline       *time.Time
+	StartedAt      *time.Time
+	Overdue        bool
+	ClonedFrom     *uuid.UUID
+	BudgetCents    *int64
+	FinishedAt     *time.Time
+	AbortReason    *string
+	AbortDebrief   string
+	AbortedAt      *time.Time
+	ApprovalStatus string
+	ScheduleID     *uuid.UUID
+	OccurrenceAt   *time.Time
+	DeletedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type UpdateMissionParams struct {
+	ID          uuid.UUID
+	Deadline    *time.Time
+	StartedAt   *time.Time
+	BudgetCents *int64
+}
+
+// Candidate is a cat free for assignment together with its track record
+type Candidate struct {
+	Cat              *cat.Cat
+	CompletedTargets int
+	TotalTargets     int
+	RecentMissions   int
+}
+
+// SalaryBasis pairs a mission with the salary of the cat assigned to it
+type SalaryBasis struct {
+	Mission     *Mission
+	SalaryCents int64
+}
+
+// NewTarget is a target stored together with its mission, its notes and their first revision are already sealed
+// for the new target ID by the caller
+type NewTarget struct {
+	ID            uuid.UUID
+	Name          string
+	Country       string
+	Notes         string
+	NotesKeyID    string
+	NotesIndex    []string
+	Revision      string
+	RevisionKeyID string
+}
+
+// NewEntity : state and timestamps are set on db level, only the type, schedule and budget are provided by the caller.
+// The mission waits for approval until the caller settles it otherwise. ScheduleID and OccurrenceAt are set by the
+// caller for missions materialized from a schedule
+func NewEntity(missionType string, deadline, startedAt *time.Time, budgetCents *int64) *Mission {
+	return &Mission{
+		Type:           missionType,
+		Deadline:       deadline,
+		StartedAt:      startedAt,
+		BudgetCents:    budgetCents,
+		ApprovalStatus: ApprovalPending,
+	}
+}
+
+func (m *Mission) Validate() error {
+	if m.Deadline != nil && m.StartedAt != nil && !m.Deadline.After(*m.StartedAt) {
+		return utils.ErrInvalidDeadline
+	}
+
+	if m.BudgetCents != nil && *m.BudgetCents < 0 {
+		return utils.ErrInvalidBudget
+	}
+
+	return nil
 }
 
-// NewEntity : here is the empty entity, because all of the values are set on db level
-func NewEntity() *Mission {
-	return &Mission{}
+// ActivePeriod is the time span the mission accrues salary for: from its start until it is finished or until now
+func (m *Mission) ActivePeriod(now time.Time) (time.Time, time.Time) {
+	from := m.CreatedAt
+	if m.StartedAt != nil {
+		from = *m.StartedAt
+	}
+
+	to := now
+	if m.FinishedAt != nil {
+		to = *m.FinishedAt
+	}
+
+	return from, to
+}
+
+// CheckEditable completed and aborted missions are read-only
+func (m *Mission) CheckEditable() error {
+	switch m.State {
+	case completedState:
+		return utils.ErrMissionCompleted
+	case abortedState:
+		return utils.ErrMissionAborted
+	default:
+		return nil
+	}
+}
+
+func ValidateAbortReason(reason string) error {
+	for _, known := range AbortReasons {
+		if reason == known {
+			return nil
+		}
+	}
+
+	return utils.ErrInvalidAbortReason
+}
+
+func (m *Mission) Update(params UpdateMissionParams) {
+	if params.Deadline != nil {
+		m.Deadline = params.Deadline
+	}
+
+	if params.StartedAt != nil {
+		m.StartedAt = params.StartedAt
+	}
+
+	if params.BudgetCents != nil {
+		m.BudgetCents = params.BudgetCents
+	}
 }
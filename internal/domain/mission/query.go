@@ -0,0 +1,122 @@
+package mission
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByUpdatedAt = "updated_at"
+
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// QuerySpec every filter is optional, the zero value lists the first page of all missions ordered by creation time
+type QuerySpec struct {
+	State          string
+	ApprovalStatus string
+	CatID          *uuid.UUID
+	UnassignedOnly bool
+	OverdueOnly    bool
+	Country        string
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	UpdatedFrom    *time.Time
+	UpdatedTo      *time.Time
+	TargetName     string
+	SortBy         string
+	SortDesc       bool
+	Cursor         *Cursor
+	Limit          int
+}
+
+// Cursor points at the last mission of the previous page by its sort value and ID
+type Cursor struct {
+	SortValue time.Time
+	ID        uuid.UUID
+}
+
+// Page Cats holds the assigned cats keyed by mission ID, unassigned missions are absent
+type Page struct {
+	Missions   []*Mission
+	Cats       map[uuid.UUID]*cat.Cat
+	NextCursor string
+}
+
+func (q *QuerySpec) Validate() error {
+	if q.State != "" && q.State != startedState && q.State != completedState && q.State != abortedState {
+		return utils.ErrInvalidQuery
+	}
+
+	if q.ApprovalStatus != "" && q.ApprovalStatus != ApprovalPending && q.ApprovalStatus != ApprovalApproved && q.ApprovalStatus != ApprovalRejected {
+		return utils.ErrInvalidQuery
+	}
+
+	if q.CatID != nil && q.UnassignedOnly {
+		return utils.ErrInvalidQuery
+	}
+
+	switch q.SortBy {
+	case "":
+		q.SortBy = SortByCreatedAt
+	case SortByCreatedAt, SortByUpdatedAt:
+	default:
+		return utils.ErrInvalidQuery
+	}
+
+	switch {
+	case q.Limit == 0:
+		q.Limit = DefaultPageSize
+	case q.Limit < 0 || q.Limit > MaxPageSize:
+		return utils.ErrInvalidQuery
+	}
+
+	return nil
+}
+
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.SortValue.UnixNano(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(encoded string) (*Cursor, error) {
+	const op = "mission.DecodeCursor"
+
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, utils.ErrInvalidCursor)
+	}
+
+	rawValue, rawID, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, utils.ErrInvalidCursor)
+	}
+
+	nanos, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, utils.ErrInvalidCursor)
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, utils.ErrInvalidCursor)
+	}
+
+	return &Cursor{SortValue: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+func (m *Mission) sortValue(sortBy string) time.Time {
+	if sortBy == SortByUpdatedAt {
+		return m.UpdatedAt
+	}
+
+	return m.CreatedAt
+}
@@ -7,22 +7,60 @@ import (
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"strings"
+	"time"
 )
 
 const (
-	tableName       = "missions"
-	idColumn        = "id"
-	catIDColumn     = "cat_id"
-	stateColumn     = "state"
-	createdAtColumn = "created_at"
-	updatedAtColumn = "updated_at"
-	completedState  = "completed"
-	startedState    = "started"
+	tableName          = "missions"
+	idColumn           = "id"
+	catIDColumn        = "cat_id"
+	stateColumn        = "state"
+	createdAtColumn    = "created_at"
+	updatedAtColumn    = "updated_at"
+	deadlineColumn     = "deadline"
+	startedAtColumn    = "started_at"
+	overdueColumn      = "overdue"
+	typeColumn         = "mission_type"
+	clonedFromColumn   = "cloned_from"
+	budgetColumn       = "budget_cents"
+	finishedAtColumn   = "finished_at"
+	abortReasonColumn  = "abort_reason"
+	abortDebriefColumn = "abort_debrief"
+	abortedAtColumn    = "aborted_at"
+	deletedAtColumn    = "deleted_at"
+	scheduleIDColumn   = "schedule_id"
+	occurrenceAtColumn = "occurrence_at"
+
+	missionAlias = "m"
+	targetAlias  = "t"
+	catAlias     = "c"
+
+	catsTableName = "cats"
+
+	targetsTableName      = "targets"
+	targetMissionIDColumn = "mission_id"
+	targetNameColumn      = "name"
+	targetCountryColumn   = "country"
+	targetNotesColumn     = "notes"
+	targetNotesKeyColumn  = "notes_key_id"
+	targetIndexColumn     = "notes_index"
+	completedState        = "completed"
+	startedState          = "started"
+	abortedState          = "aborted"
+
+	revisionsTableName = "target_notes_revisions"
+	revTargetIDColumn  = "target_id"
+	revRevisionColumn  = "revision"
+	revAuthorColumn    = "author"
+	revContentColumn   = "content"
+	revKeyIDColumn     = "content_key_id"
 )
 
 type Repository struct {
@@ -39,14 +77,111 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 	}
 }
 
-func (r *Repository) AddMission(ctx context.Context) (uuid.UUID, error) {
+var selectColumns = []string{
+	idColumn,
+	catIDColumn,
+	typeColumn,
+	stateColumn,
+	deadlineColumn,
+	startedAtColumn,
+	overdueColumn,
+	clonedFromColumn,
+	budgetColumn,
+	finishedAtColumn,
+	abortReasonColumn,
+	abortDebriefColumn,
+	abortedAtColumn,
+	approvalStatusColumn,
+	scheduleIDColumn,
+	occurrenceAtColumn,
+	deletedAtColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+var catColumns = []string{"id", "name", "experience", "breed", "salary", "created_at", "updated_at"}
+
+// nullableCat receives the cat columns of a LEFT JOIN which are all NULL for unassigned missions
+type nullableCat struct {
+	ID          *uuid.UUID
+	Name        *string
+	YearsXP     *int
+	Breed       *string
+	SalaryCents *int64
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
+
+func (c nullableCat) toCat() *cat.Cat {
+	assignedCat := &cat.Cat{ID: *c.ID}
+
+	if c.Name != nil {
+		assignedCat.Name = *c.Name
+	}
+	if c.YearsXP != nil {
+		assignedCat.YearsXP = *c.YearsXP
+	}
+	if c.Breed != nil {
+		assignedCat.Breed = *c.Breed
+	}
+	if c.SalaryCents != nil {
+		assignedCat.SalaryCents = *c.SalaryCents
+	}
+	if c.CreatedAt != nil {
+		assignedCat.CreatedAt = *c.CreatedAt
+	}
+	if c.UpdatedAt != nil {
+		assignedCat.UpdatedAt = *c.UpdatedAt
+	}
+
+	return assignedCat
+}
+
+// scanDest follows the order of selectColumns
+func scanDest(mission *Mission) []interface{} {
+	return []interface{}{
+		&mission.ID,
+		&mission.CatID,
+		&mission.Type,
+		&mission.State,
+		&mission.Deadline,
+		&mission.StartedAt,
+		&mission.Overdue,
+		&mission.ClonedFrom,
+		&mission.BudgetCents,
+		&mission.FinishedAt,
+		&mission.AbortReason,
+		&mission.AbortDebrief,
+		&mission.AbortedAt,
+		&mission.ApprovalStatus,
+		&mission.ScheduleID,
+		&mission.OccurrenceAt,
+		&mission.DeletedAt,
+		&mission.CreatedAt,
+		&mission.UpdatedAt,
+	}
+}
+
+func scanMission(row pgx.Row, mission *Mission) error {
+	return row.Scan(scanDest(mission)...)
+}
+
+// AddMission stores the mission together with its targets in one transaction, the notes of the targets start
+// their notes history on behalf of author. A second mission for the same schedule occurrence is rejected
+func (r *Repository) AddMission(ctx context.Context, mission *Mission, targets []*NewTarget, author string) (uuid.UUID, error) {
 	const op = "mission.Repository.AddMission"
 	var id uuid.UUID
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
 	query, args, err := r.builder.
 		Insert(tableName).
-		Columns(stateColumn).
-		Values(startedState).
+		Columns(stateColumn, typeColumn, deadlineColumn, startedAtColumn, budgetColumn, approvalStatusColumn, scheduleIDColumn, occurrenceAtColumn).
+		Values(startedState, mission.Type, mission.Deadline, mission.StartedAt, mission.BudgetCents, mission.ApprovalStatus, mission.ScheduleID, mission.OccurrenceAt).
 		Suffix("RETURNING " + idColumn).
 		ToSql()
 
@@ -54,14 +189,107 @@ func (r *Repository) AddMission(ctx context.Context) (uuid.UUID, error) {
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = r.db.QueryRow(ctx, query, args...).Scan(&id)
+	err = tx.QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrConflictingData)
+		}
+
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.insertTargets(ctx, tx, id, targets, author); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return id, nil
 }
 
+// CloneMission stores a new started mission without a cat cloned from the source one together with the copies of
+// its targets in one transaction, the copied notes start the notes history of the new targets on behalf of author
+func (r *Repository) CloneMission(ctx context.Context, sourceID uuid.UUID, targets []*NewTarget, author string) (uuid.UUID, error) {
+	const op = "mission.Repository.CloneMission"
+	var id uuid.UUID
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	missionQuery, missionArgs, err := r.builder.
+		Insert(tableName).
+		Columns(stateColumn, typeColumn, clonedFromColumn).
+		Select(r.builder.
+			Select().
+			Column(sq.Expr("?", startedState)).
+			Column(typeColumn).
+			Column(idColumn).
+			From(tableName).
+			Where(sq.Eq{idColumn: sourceID, deletedAtColumn: nil})).
+		Suffix("RETURNING " + idColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.QueryRow(ctx, missionQuery, missionArgs...).Scan(&id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.insertTargets(ctx, tx, id, targets, author); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) insertTargets(ctx context.Context, tx pgx.Tx, missionID uuid.UUID, targets []*NewTarget, author string) error {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	targetsInsert := r.builder.
+		Insert(targetsTableName).
+		Columns(idColumn, targetMissionIDColumn, targetNameColumn, targetCountryColumn, targetNotesColumn, targetNotesKeyColumn, targetIndexColumn)
+
+	revisionsInsert := r.builder.
+		Insert(revisionsTableName).
+		Columns(revTargetIDColumn, revRevisionColumn, revAuthorColumn, revContentColumn, revKeyIDColumn)
+
+	for _, t := range targets {
+		targetsInsert = targetsInsert.Values(t.ID, missionID, t.Name, t.Country, t.Notes, t.NotesKeyID, t.NotesIndex)
+		revisionsInsert = revisionsInsert.Values(t.ID, 1, author, t.Revision, t.RevisionKeyID)
+	}
+
+	for _, insert := range []sq.InsertBuilder{targetsInsert, revisionsInsert} {
+		query, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMission moves the mission into the trash, its targets are kept as they are so it can be restored
 func (r *Repository) DeleteMission(ctx context.Context, id uuid.UUID) error {
 	const op = "mission.Repository.DeleteMission"
 
@@ -69,14 +297,24 @@ func (r *Repository) DeleteMission(ctx context.Context, id uuid.UUID) error {
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	defer tx.Rollback(ctx)
+
+	checkQuery, checkArgs, err := r.builder.
+		Select(catIDColumn).
+		From(tableName).
+		Where(sq.Eq{idColumn: id, deletedAtColumn: nil}).
+		Suffix("FOR UPDATE").
+		ToSql()
 
-	checkQuery, checkArgs, err := r.builder.Select(catIDColumn).From(tableName).Where(sq.Eq{idColumn: id}).ToSql()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	var catID sql.Null[uuid.UUID]
 	if err = tx.QueryRow(ctx, checkQuery, checkArgs...).Scan(&catID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -84,17 +322,17 @@ func (r *Repository) DeleteMission(ctx context.Context, id uuid.UUID) error {
 		return utils.ErrCatAssigned
 	}
 
-	delQuery, delArgs, err := r.builder.Delete(tableName).Where(sq.Eq{idColumn: id}).ToSql()
+	trashQuery, trashArgs, err := r.builder.
+		Update(tableName).
+		Set(deletedAtColumn, sq.Expr("now()")).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
 	if err != nil {
-		tx.Rollback(ctx)
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if _, err = tx.Exec(ctx, delQuery, delArgs...); err != nil {
-		defer tx.Rollback(ctx)
-		if errors.Is(err, pgx.ErrNoRows) {
-			return utils.ErrMissionNotFound
-		}
+	if _, err = tx.Exec(ctx, trashQuery, trashArgs...); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -109,6 +347,7 @@ func (r *Repository) SetMissionCompleted(ctx context.Context, id uuid.UUID) erro
 
 	query, args, err := r.builder.Update(tableName).
 		Set(stateColumn, completedState).
+		Set(finishedAtColumn, sq.Expr("COALESCE("+finishedAtColumn+", now())")).
 		Where(sq.Eq{idColumn: id}).
 		ToSql()
 
@@ -129,6 +368,61 @@ func (r *Repository) SetMissionCompleted(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
+// AbortMission stops a started mission and abandons its open targets in one transaction,
+// the cat stays on the mission for reporting but is free for new missions
+func (r *Repository) AbortMission(ctx context.Context, id uuid.UUID, reason, debrief string) error {
+	const op = "mission.Repository.AbortMission"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(stateColumn, abortedState).
+		Set(abortReasonColumn, reason).
+		Set(abortDebriefColumn, debrief).
+		Set(abortedAtColumn, sq.Expr("now()")).
+		Set(finishedAtColumn, sq.Expr("COALESCE("+finishedAtColumn+", now())")).
+		Where(sq.Eq{idColumn: id, stateColumn: startedState}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+	}
+
+	query, args, err = r.builder.
+		Update(targetsTableName).
+		Set(stateColumn, target.StateAbandoned).
+		Where(sq.Eq{targetMissionIDColumn: id, stateColumn: target.OpenStates}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
 func (r *Repository) AddCatID(ctx context.Context, missionID uuid.UUID, catID uuid.UUID) error {
 	const op = "mission.Repository.AddCatID"
 
@@ -143,6 +437,12 @@ func (r *Repository) AddCatID(ctx context.Context, missionID uuid.UUID, catID uu
 	}
 
 	if _, err = r.db.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, utils.ErrCatUnavailable)
+			}
+		}
 		if errors.Is(err, sql.ErrNoRows) {
 			return utils.ErrMissionNotFound
 		}
@@ -152,51 +452,246 @@ func (r *Repository) AddCatID(ctx context.Context, missionID uuid.UUID, catID uu
 	return nil
 }
 
-func (r *Repository) GetMissions(ctx context.Context) ([]*Mission, error) {
+// GetMissions applies the spec filters and loads assigned cats in the same query,
+// targets are joined only when one of the target filters is set
+func (r *Repository) GetMissions(ctx context.Context, spec QuerySpec) (*Page, error) {
 	const op = "mission.Repository.GetMissions"
-	missions := make([]*Mission, 0)
 
-	query, args, err := r.builder.
-		Select(idColumn, stateColumn, createdAtColumn, updatedAtColumn).
-		From(tableName).
-		ToSql()
+	sortColumn := missionAlias + "." + spec.SortBy
+	idRef := missionAlias + "." + idColumn
+	direction := "ASC"
+	if spec.SortDesc {
+		direction = "DESC"
+	}
+
+	// the cat columns depend on the mission row only, so they don't break the DISTINCT of the target filters
+	builder := r.builder.
+		Select(qualified(missionAlias, selectColumns)...).
+		Columns(qualified(catAlias, catColumns)...).
+		From(tableName+" "+missionAlias).
+		LeftJoin(fmt.Sprintf("%s %s ON %s.%s = %s.%s", catsTableName, catAlias, catAlias, idColumn, missionAlias, catIDColumn)).
+		Where(sq.Eq{missionAlias + "." + deletedAtColumn: nil}).
+		OrderBy(sortColumn+" "+direction, idRef+" "+direction).
+		Limit(uint64(spec.Limit) + 1)
+
+	if spec.State != "" {
+		builder = builder.Where(sq.Eq{missionAlias + "." + stateColumn: spec.State})
+	}
+
+	if spec.ApprovalStatus != "" {
+		builder = builder.Where(sq.Eq{missionAlias + "." + approvalStatusColumn: spec.ApprovalStatus})
+	}
+
+	if spec.CatID != nil {
+		builder = builder.Where(sq.Eq{missionAlias + "." + catIDColumn: *spec.CatID})
+	}
+
+	if spec.UnassignedOnly {
+		builder = builder.Where(sq.Eq{missionAlias + "." + catIDColumn: nil})
+	}
+
+	if spec.OverdueOnly {
+		builder = builder.
+			Where(sq.Eq{missionAlias + "." + overdueColumn: true}).
+			Where(sq.Eq{missionAlias + "." + stateColumn: startedState})
+	}
+
+	if spec.CreatedFrom != nil {
+		builder = builder.Where(sq.GtOrEq{missionAlias + "." + createdAtColumn: *spec.CreatedFrom})
+	}
+
+	if spec.CreatedTo != nil {
+		builder = builder.Where(sq.Lt{missionAlias + "." + createdAtColumn: *spec.CreatedTo})
+	}
+
+	if spec.UpdatedFrom != nil {
+		builder = builder.Where(sq.GtOrEq{missionAlias + "." + updatedAtColumn: *spec.UpdatedFrom})
+	}
 
+	if spec.UpdatedTo != nil {
+		builder = builder.Where(sq.Lt{missionAlias + "." + updatedAtColumn: *spec.UpdatedTo})
+	}
+
+	if spec.Country != "" || spec.TargetName != "" {
+		builder = builder.
+			Distinct().
+			Join(fmt.Sprintf("%s %s ON %s.%s = %s", targetsTableName, targetAlias, targetAlias, targetMissionIDColumn, idRef))
+
+		if spec.Country != "" {
+			builder = builder.Where(sq.Expr(fmt.Sprintf("lower(%s.%s) = lower(?)", targetAlias, targetCountryColumn), spec.Country))
+		}
+
+		if spec.TargetName != "" {
+			builder = builder.Where(sq.ILike{targetAlias + "." + targetNameColumn: "%" + escapeLike(spec.TargetName) + "%"})
+		}
+	}
+
+	if spec.Cursor != nil {
+		comparison := ">"
+		if spec.SortDesc {
+			comparison = "<"
+		}
+
+		builder = builder.Where(
+			sq.Expr(fmt.Sprintf("(%s, %s) %s (?, ?)", sortColumn, idRef, comparison), spec.Cursor.SortValue, spec.Cursor.ID),
+		)
+	}
+
+	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var rows pgx.Rows
-	rows, err = r.db.Query(ctx, query, args...)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
+	missions := make([]*Mission, 0, spec.Limit)
+	cats := make(map[uuid.UUID]*cat.Cat)
 	for rows.Next() {
 		var mission Mission
-
-		err = rows.Scan(&mission.ID, &mission.State, &mission.CreatedAt, &mission.UpdatedAt)
+		var assignedCat nullableCat
+
+		err = rows.Scan(append(
+			scanDest(&mission),
+			&assignedCat.ID,
+			&assignedCat.Name,
+			&assignedCat.YearsXP,
+			&assignedCat.Breed,
+			&assignedCat.SalaryCents,
+			&assignedCat.CreatedAt,
+			&assignedCat.UpdatedAt,
+		)...)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
 		missions = append(missions, &mission)
+		if assignedCat.ID != nil {
+			cats[mission.ID] = assignedCat.toCat()
+		}
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	page := &Page{Missions: missions, Cats: cats}
+	if len(missions) > spec.Limit {
+		page.Missions = missions[:spec.Limit]
+
+		last := page.Missions[len(page.Missions)-1]
+		page.NextCursor = Cursor{SortValue: last.sortValue(spec.SortBy), ID: last.ID}.Encode()
+	}
+
+	return page, nil
+}
+
+// MarkOverdueMissions flags open missions whose deadline has passed and returns only the ones flagged by this call
+func (r *Repository) MarkOverdueMissions(ctx context.Context) ([]*Mission, error) {
+	const op = "mission.Repository.MarkOverdueMissions"
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(overdueColumn, true).
+		Where(sq.Eq{overdueColumn: false}).
+		Where(sq.Eq{stateColumn: startedState, deletedAtColumn: nil}).
+		Where(sq.Expr(deadlineColumn + " < now()")).
+		Suffix("RETURNING " + strings.Join(selectColumns, ", ")).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	missions, err := r.queryMissions(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return missions, nil
 }
 
+func (r *Repository) UpdateMission(ctx context.Context, mission *Mission) error {
+	const op = "mission.Repository.UpdateMission"
+
+	// the overdue flag is reset, the worker will raise it again if the new deadline is already missed
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(deadlineColumn, mission.Deadline).
+		Set(startedAtColumn, mission.StartedAt).
+		Set(budgetColumn, mission.BudgetCents).
+		Set(overdueColumn, false).
+		Where(sq.Eq{idColumn: mission.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var res pgconn.CommandTag
+	res, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+	}
+
+	return nil
+}
+
+func qualified(alias string, columns []string) []string {
+	qualifiedColumns := make([]string, 0, len(columns))
+	for _, column := range columns {
+		qualifiedColumns = append(qualifiedColumns, alias+"."+column)
+	}
+
+	return qualifiedColumns
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+func (r *Repository) queryMissions(ctx context.Context, query string, args ...interface{}) ([]*Mission, error) {
+	missions := make([]*Mission, 0)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var mission Mission
+
+		if err = scanMission(rows, &mission); err != nil {
+			return nil, err
+		}
+
+		missions = append(missions, &mission)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return missions, nil
+}
+
+// GetMissionByCatID returns the mission the cat is working on, a cat keeps its finished missions but can have
+// only one started at a time
 func (r *Repository) GetMissionByCatID(ctx context.Context, catID uuid.UUID) (*Mission, error) {
 	const op = "mission.Repository.GetMissionsByCatID"
 
 	query, args, err := r.builder.
-		Select(idColumn, stateColumn, createdAtColumn, updatedAtColumn).
+		Select(selectColumns...).
 		From(tableName).
-		Where(sq.Eq{catIDColumn: catID}).
+		Where(sq.Eq{catIDColumn: catID, stateColumn: startedState, deletedAtColumn: nil}).
 		ToSql()
 
 	if err != nil {
@@ -204,7 +699,7 @@ func (r *Repository) GetMissionByCatID(ctx context.Context, catID uuid.UUID) (*M
 	}
 
 	var mission Mission
-	err = r.db.QueryRow(ctx, query, args...).Scan(&mission.ID, &mission.State, &mission.CreatedAt, &mission.UpdatedAt)
+	err = scanMission(r.db.QueryRow(ctx, query, args...), &mission)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, utils.ErrCatNotFound
@@ -220,21 +715,44 @@ func (r *Repository) GetMissionByID(ctx context.Context, id uuid.UUID) (*Mission
 	var mission Mission
 
 	query, args, err := r.builder.
-		Select(idColumn, stateColumn, createdAtColumn, updatedAtColumn).
+		Select(selectColumns...).
 		From(tableName).
-		Where(sq.Eq{idColumn: id}).
+		Where(sq.Eq{idColumn: id, deletedAtColumn: nil}).
 		ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = r.db.QueryRow(ctx, query, args...).Scan(
-		&mission.ID,
-		&mission.State,
-		&mission.CreatedAt,
-		&mission.UpdatedAt,
-	)
+	err = scanMission(r.db.QueryRow(ctx, query, args...), &mission)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &mission, nil
+}
+
+// GetMissionByOccurrence returns the mission materialized for the schedule occurrence, trashed ones included since
+// the occurrence must not be materialized again
+func (r *Repository) GetMissionByOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time) (*Mission, error) {
+	const op = "mission.Repository.GetMissionByOccurrence"
+	var mission Mission
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{scheduleIDColumn: scheduleID, occurrenceAtColumn: occurrence}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = scanMission(r.db.QueryRow(ctx, query, args...), &mission)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
@@ -250,8 +768,8 @@ func (r *Repository) GetAssignedCat(ctx context.Context, missionID uuid.UUID) (*
 	const op = "cat.Repository.GetAssignedCat"
 	var assignedCat cat.Cat
 
-	query := fmt.Sprintf(`SELECT c.id, c.name, c.experience, c.breed, c.salary, c.created_at, c.updated_at" +
-		"FROM cats c JOIN missions m ON m.cat_id = c.id WHERE m.id = $1`)
+	query := `SELECT c.id, c.name, c.experience, c.breed, c.salary, c.created_at, c.updated_at
+		FROM cats c JOIN missions m ON m.cat_id = c.id WHERE m.id = $1`
 
 	args := []interface{}{
 		missionID,
@@ -277,3 +795,109 @@ func (r *Repository) GetAssignedCat(ctx context.Context, missionID uuid.UUID) (*
 
 	return &assignedCat, nil
 }
+
+// GetAssignmentCandidates lists cats without an active mission, their results on targets located in the given
+// countries and the amount of missions they took since the given moment
+func (r *Repository) GetAssignmentCandidates(ctx context.Context, countries []string, since time.Time) ([]*Candidate, error) {
+	const op = "mission.Repository.GetAssignmentCandidates"
+
+	query := `SELECT c.id, c.name, COALESCE(c.experience, 0), c.breed, c.salary, c.created_at, c.updated_at,
+			COALESCE(h.completed_targets, 0), COALESCE(h.total_targets, 0), COALESCE(l.recent_missions, 0)
+		FROM cats c
+		LEFT JOIN (
+			SELECT m.cat_id,
+				COUNT(*) FILTER (WHERE t.state::text = ANY($3)) AS completed_targets,
+				COUNT(*) AS total_targets
+			FROM missions m JOIN targets t ON t.mission_id = m.id
+			WHERE t.country = ANY($1)
+			GROUP BY m.cat_id
+		) h ON h.cat_id = c.id
+		LEFT JOIN (
+			SELECT cat_id, COUNT(*) AS recent_missions FROM missions WHERE created_at >= $2 GROUP BY cat_id
+		) l ON l.cat_id = c.id
+		WHERE NOT EXISTS (SELECT 1 FROM missions a WHERE a.cat_id = c.id AND a.state = 'started')`
+
+	rows, err := r.db.Query(ctx, query, countries, since, target.SuccessfulStates)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	candidates := make([]*Candidate, 0)
+	for rows.Next() {
+		var candidateCat cat.Cat
+		candidate := Candidate{Cat: &candidateCat}
+
+		err = rows.Scan(
+			&candidateCat.ID,
+			&candidateCat.Name,
+			&candidateCat.YearsXP,
+			&candidateCat.Breed,
+			&candidateCat.SalaryCents,
+			&candidateCat.CreatedAt,
+			&candidateCat.UpdatedAt,
+			&candidate.CompletedTargets,
+			&candidate.TotalTargets,
+			&candidate.RecentMissions,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		candidates = append(candidates, &candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return candidates, nil
+}
+
+// GetAssignedMissionsActiveBetween lists missions with a cat whose active period overlaps [from, to),
+// each one is paired with the salary of its cat
+func (r *Repository) GetAssignedMissionsActiveBetween(ctx context.Context, from, to time.Time) ([]*SalaryBasis, error) {
+	const op = "mission.Repository.GetAssignedMissionsActiveBetween"
+
+	startExpr := fmt.Sprintf("COALESCE(%s.%s, %s.%s)", missionAlias, startedAtColumn, missionAlias, createdAtColumn)
+
+	query, args, err := r.builder.
+		Select(qualified(missionAlias, selectColumns)...).
+		Column(catAlias + ".salary").
+		From(tableName + " " + missionAlias).
+		Join(fmt.Sprintf("%s %s ON %s.%s = %s.%s", catsTableName, catAlias, catAlias, idColumn, missionAlias, catIDColumn)).
+		Where(sq.Expr(startExpr+" < ?", to)).
+		Where(sq.Or{
+			sq.Eq{missionAlias + "." + finishedAtColumn: nil},
+			sq.GtOrEq{missionAlias + "." + finishedAtColumn: from},
+		}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	bases := make([]*SalaryBasis, 0)
+	for rows.Next() {
+		var mission Mission
+		basis := SalaryBasis{Mission: &mission}
+
+		if err = rows.Scan(append(scanDest(&mission), &basis.SalaryCents)...); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		bases = append(bases, &basis)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return bases, nil
+}
@@ -0,0 +1,141 @@
+package mission
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+// GetTrashedMissions lists the deleted missions, the most recently deleted first
+func (r *Repository) GetTrashedMissions(ctx context.Context) ([]*Mission, error) {
+	const op = "mission.Repository.GetTrashedMissions"
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.NotEq{deletedAtColumn: nil}).
+		OrderBy(deletedAtColumn+" DESC", idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	missions, err := r.queryMissions(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return missions, nil
+}
+
+// RestoreMission takes the mission out of the trash, its targets were never touched
+func (r *Repository) RestoreMission(ctx context.Context, id uuid.UUID) error {
+	const op = "mission.Repository.RestoreMission"
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(deletedAtColumn, nil).
+		Where(sq.Eq{idColumn: id}).
+		Where(sq.NotEq{deletedAtColumn: nil}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+	}
+
+	return nil
+}
+
+// PurgeTrash hard-deletes the missions trashed before the given time together with their targets.
+// The purged rows are locked first, so a concurrent restore either wins or finds nothing to restore
+func (r *Repository) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	const op = "mission.Repository.PurgeTrash"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.builder.
+		Select(idColumn).
+		From(tableName).
+		Where(sq.Lt{deletedAtColumn: before}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ids := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%s: %w", op, err)
+		}
+
+		ids = append(ids, id)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	query, args, err = r.builder.
+		Delete(targetsTableName).
+		Where(sq.Expr(targetMissionIDColumn+" = ANY(?)", ids)).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err = r.builder.
+		Delete(tableName).
+		Where(sq.Expr(idColumn+" = ANY(?)", ids)).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res.RowsAffected(), nil
+}
@@ -0,0 +1,177 @@
+package schedule
+
+import (
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard five field expression: minute, hour, day of month, month, day of week.
+// Fields accept *, single values, a-b ranges, lists and /n steps; day of week 7 means Sunday like 0
+type Cron struct {
+	minutes, hours, days, months, weekdays uint64
+	// when both day fields are restricted a day matches if either of them does, as in classic cron
+	anyDay, anyWeekday bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+type cronBounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds  = cronBounds{0, 59}
+	hourBounds    = cronBounds{0, 23}
+	dayBounds     = cronBounds{1, 31}
+	monthBounds   = cronBounds{1, 12}
+	weekdayBounds = cronBounds{0, 7}
+)
+
+// maxSearchYears bounds Next for expressions like "0 0 30 2 *" which never match
+const maxSearchYears = 5
+
+func ParseCron(expr string) (*Cron, error) {
+	const op = "schedule.ParseCron"
+
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%s: %w", op, utils.ErrInvalidCron)
+	}
+
+	var c Cron
+	var err error
+
+	parsers := []struct {
+		field  string
+		bounds cronBounds
+		dest   *uint64
+	}{
+		{fields[0], minuteBounds, &c.minutes},
+		{fields[1], hourBounds, &c.hours},
+		{fields[2], dayBounds, &c.days},
+		{fields[3], monthBounds, &c.months},
+		{fields[4], weekdayBounds, &c.weekdays},
+	}
+
+	for _, p := range parsers {
+		if *p.dest, err = parseCronField(p.field, p.bounds); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+
+	c.anyDay = fields[2] == "*"
+	c.anyWeekday = fields[4] == "*"
+
+	return &c, nil
+}
+
+func parseCronField(field string, bounds cronBounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step <= 0 {
+				return 0, utils.ErrInvalidCron
+			}
+		}
+
+		from, to := bounds.min, bounds.max
+		if rangePart != "*" {
+			rawFrom, rawTo, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if from, err = strconv.Atoi(rawFrom); err != nil {
+				return 0, utils.ErrInvalidCron
+			}
+
+			to = from
+			if isRange {
+				if to, err = strconv.Atoi(rawTo); err != nil {
+					return 0, utils.ErrInvalidCron
+				}
+			} else if hasStep {
+				to = bounds.max
+			}
+		}
+
+		if from < bounds.min || to > bounds.max || from > to {
+			return 0, utils.ErrInvalidCron
+		}
+
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// Next returns the first matching minute strictly after t, evaluated in t's location.
+// The zero time is returned when nothing matches within a few years
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !has(c.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(c.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(c.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	dayOk := has(c.days, t.Day())
+	weekdayOk := has(c.weekdays, int(t.Weekday()))
+
+	if c.anyDay || c.anyWeekday {
+		return dayOk && weekdayOk
+	}
+
+	return dayOk || weekdayOk
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
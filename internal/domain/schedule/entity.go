@@ -0,0 +1,171 @@
+package schedule
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/template"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Schedule materializes missions either once at RunAt or on every Cron occurrence (UTC),
+// NextRunAt is nil once a one-off schedule has run
+type Schedule struct {
+	ID            uuid.UUID
+	Name          string `validate:"required"`
+	MissionType   string
+	Targets       []template.TargetSkeleton `validate:"required,dive"`
+	BudgetCents   *int64
+	DeadlineAfter *time.Duration
+	Cron          *string
+	RunAt         *time.Time
+	NextRunAt     *time.Time
+	AutoAssign    bool
+	Paused        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Claim Settled means the occurrence already has its mission or was given up, so the schedule can move past it.
+// Attempts counts every claim of the occurrence including the ones of runs that crashed
+type Claim struct {
+	Acquired bool
+	Settled  bool
+	Attempts int
+}
+
+type CreateScheduleSvc struct {
+	Name          string
+	MissionType   string
+	Targets       []template.TargetSkeleton
+	BudgetCents   *int64
+	DeadlineAfter *time.Duration
+	Cron          *string
+	RunAt         *time.Time
+	AutoAssign    bool
+}
+
+func NewEntity(req CreateScheduleSvc) *Schedule {
+	return &Schedule{
+		Name:          req.Name,
+		MissionType:   req.MissionType,
+		Targets:       req.Targets,
+		BudgetCents:   req.BudgetCents,
+		DeadlineAfter: req.DeadlineAfter,
+		Cron:          req.Cron,
+		RunAt:         req.RunAt,
+		AutoAssign:    req.AutoAssign,
+	}
+}
+
+func (s *Schedule) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(s); err != nil {
+		return utils.ErrValidatingSchedule
+	}
+
+	if len(s.Targets) == 0 || len(s.Targets) > mission.MissionSize {
+		return utils.ErrValidatingSchedule
+	}
+
+	if (s.Cron == nil) == (s.RunAt == nil) {
+		return utils.ErrValidatingSchedule
+	}
+
+	if s.Cron != nil {
+		if _, err := ParseCron(*s.Cron); err != nil {
+			return err
+		}
+	}
+
+	if s.BudgetCents != nil && *s.BudgetCents < 0 {
+		return utils.ErrInvalidBudget
+	}
+
+	if s.DeadlineAfter != nil && *s.DeadlineAfter <= 0 {
+		return utils.ErrInvalidDeadline
+	}
+
+	return nil
+}
+
+// Plan sets the first occurrence after now, a one-off schedule keeps its time even if it has already passed
+func (s *Schedule) Plan(now time.Time) error {
+	if s.RunAt != nil {
+		s.NextRunAt = s.RunAt
+		return nil
+	}
+
+	next, err := s.nextAfter(now)
+	if err != nil {
+		return err
+	}
+
+	// an expression like "0 0 31 4 *" parses but never fires
+	if next == nil {
+		return utils.ErrInvalidCron
+	}
+
+	s.NextRunAt = next
+	return nil
+}
+
+// Resume re-plans a cron schedule from now so occurrences missed while paused are not run,
+// a one-off schedule that has not run yet keeps its time
+func (s *Schedule) Resume(now time.Time) error {
+	s.Paused = false
+
+	if s.Cron == nil {
+		return nil
+	}
+
+	return s.Plan(now)
+}
+
+// Advance moves past the given occurrence. Occurrences missed while the service was down are skipped,
+// only the one that was due gets materialized
+func (s *Schedule) Advance(occurrence, now time.Time) error {
+	if s.Cron == nil {
+		s.NextRunAt = nil
+		return nil
+	}
+
+	from := occurrence
+	if now.After(from) {
+		from = now
+	}
+
+	next, err := s.nextAfter(from)
+	if err != nil {
+		return err
+	}
+
+	s.NextRunAt = next
+	return nil
+}
+
+// Deadline of a materialized occurrence, nil when the schedule sets none
+func (s *Schedule) Deadline(occurrence time.Time) *time.Time {
+	if s.DeadlineAfter == nil {
+		return nil
+	}
+
+	deadline := occurrence.Add(*s.DeadlineAfter)
+	return &deadline
+}
+
+func (s *Schedule) nextAfter(t time.Time) (*time.Time, error) {
+	cron, err := ParseCron(*s.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	next := cron.Next(t.UTC())
+	if next.IsZero() {
+		return nil, nil
+	}
+
+	return &next, nil
+}
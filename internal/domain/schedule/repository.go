@@ -0,0 +1,439 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type Repository struct {
+	db      *pgxpool.Pool
+	builder sq.StatementBuilderType
+}
+
+const (
+	tableName                  = "mission_schedules"
+	idColumn                   = "id"
+	nameColumn                 = "name"
+	missionTypeColumn          = "mission_type"
+	targetsColumn              = "targets"
+	budgetCentsColumn          = "budget_cents"
+	deadlineAfterSecondsColumn = "deadline_after_seconds"
+	cronExprColumn             = "cron_expr"
+	runAtColumn                = "run_at"
+	nextRunAtColumn            = "next_run_at"
+	autoAssignColumn           = "auto_assign"
+	pausedColumn               = "paused"
+	createdAtColumn            = "created_at"
+	updatedAtColumn            = "updated_at"
+
+	runsTableName         = "mission_schedule_runs"
+	runScheduleIDColumn   = "schedule_id"
+	runOccurrenceAtColumn = "occurrence_at"
+	runMissionIDColumn    = "mission_id"
+	runClaimedAtColumn    = "claimed_at"
+	runAttemptsColumn     = "attempts"
+	runLastErrorColumn    = "last_error"
+	runFailedAtColumn     = "failed_at"
+)
+
+var selectColumns = []string{
+	idColumn,
+	nameColumn,
+	missionTypeColumn,
+	targetsColumn,
+	budgetCentsColumn,
+	deadlineAfterSecondsColumn,
+	cronExprColumn,
+	runAtColumn,
+	nextRunAtColumn,
+	autoAssignColumn,
+	pausedColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	builder := sq.StatementBuilderType{}
+	builder = builder.PlaceholderFormat(sq.Dollar)
+	return &Repository{db: pool, builder: builder}
+}
+
+func scanSchedule(row pgx.Row, sch *Schedule) error {
+	var rawTargets []byte
+	var deadlineAfterSeconds *int64
+
+	err := row.Scan(
+		&sch.ID,
+		&sch.Name,
+		&sch.MissionType,
+		&rawTargets,
+		&sch.BudgetCents,
+		&deadlineAfterSeconds,
+		&sch.Cron,
+		&sch.RunAt,
+		&sch.NextRunAt,
+		&sch.AutoAssign,
+		&sch.Paused,
+		&sch.CreatedAt,
+		&sch.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if deadlineAfterSeconds != nil {
+		deadlineAfter := time.Duration(*deadlineAfterSeconds) * time.Second
+		sch.DeadlineAfter = &deadlineAfter
+	}
+
+	return json.Unmarshal(rawTargets, &sch.Targets)
+}
+
+func (r *Repository) AddSchedule(ctx context.Context, sch *Schedule) (uuid.UUID, error) {
+	const op = "schedule.Repository.AddSchedule"
+	var id uuid.UUID
+
+	rawTargets, err := json.Marshal(sch.Targets)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var deadlineAfterSeconds *int64
+	if sch.DeadlineAfter != nil {
+		seconds := int64(sch.DeadlineAfter.Seconds())
+		deadlineAfterSeconds = &seconds
+	}
+
+	query, args, err := r.builder.
+		Insert(tableName).
+		Columns(
+			nameColumn,
+			missionTypeColumn,
+			targetsColumn,
+			budgetCentsColumn,
+			deadlineAfterSecondsColumn,
+			cronExprColumn,
+			runAtColumn,
+			nextRunAtColumn,
+			autoAssignColumn,
+		).
+		Values(
+			sch.Name,
+			sch.MissionType,
+			rawTargets,
+			sch.BudgetCents,
+			deadlineAfterSeconds,
+			sch.Cron,
+			sch.RunAt,
+			sch.NextRunAt,
+			sch.AutoAssign,
+		).
+		Suffix("RETURNING " + idColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*Schedule, error) {
+	const op = "schedule.Repository.GetScheduleByID"
+	var sch Schedule
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = scanSchedule(r.db.QueryRow(ctx, query, args...), &sch); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrScheduleNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &sch, nil
+}
+
+func (r *Repository) GetSchedules(ctx context.Context) ([]*Schedule, error) {
+	const op = "schedule.Repository.GetSchedules"
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		OrderBy(createdAtColumn, idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedules, err := r.querySchedules(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return schedules, nil
+}
+
+// GetDueSchedules returns active schedules whose next occurrence is not later than now
+func (r *Repository) GetDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	const op = "schedule.Repository.GetDueSchedules"
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{pausedColumn: false}).
+		Where(sq.LtOrEq{nextRunAtColumn: now}).
+		OrderBy(nextRunAtColumn, idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	schedules, err := r.querySchedules(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return schedules, nil
+}
+
+func (r *Repository) querySchedules(ctx context.Context, query string, args []interface{}) ([]*Schedule, error) {
+	schedules := make([]*Schedule, 0)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sch Schedule
+
+		if err = scanSchedule(rows, &sch); err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, &sch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// UpdateScheduleState persists the paused flag together with the re-planned next occurrence
+func (r *Repository) UpdateScheduleState(ctx context.Context, sch *Schedule) error {
+	const op = "schedule.Repository.UpdateScheduleState"
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(pausedColumn, sch.Paused).
+		Set(nextRunAtColumn, sch.NextRunAt).
+		Where(sq.Eq{idColumn: sch.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrScheduleNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	const op = "schedule.Repository.DeleteSchedule"
+
+	query, args, err := r.builder.Delete(tableName).Where(sq.Eq{idColumn: id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrScheduleNotFound)
+	}
+
+	return nil
+}
+
+// ClaimOccurrence reserves the occurrence for materialization. An occurrence claimed by an earlier run is
+// claimed again when that run failed or its claim is older than staleAfter without a mission, which is what a
+// crash before the mission is linked to the run leaves behind. The mission such a run may have stored already
+// carries the occurrence, so the caller adopts it instead of creating another one
+func (r *Repository) ClaimOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time, staleAfter time.Duration) (*Claim, error) {
+	const op = "schedule.Repository.ClaimOccurrence"
+
+	run := func(column string) string { return runsTableName + "." + column }
+
+	query, args, err := r.builder.
+		Insert(runsTableName).
+		Columns(runScheduleIDColumn, runOccurrenceAtColumn).
+		Values(scheduleID, occurrence).
+		Suffix(fmt.Sprintf(
+			"ON CONFLICT (%s, %s) DO UPDATE SET %s = now(), %s = %s + 1 "+
+				"WHERE %s IS NULL AND %s IS NULL AND (%s IS NULL OR %s < now() - make_interval(secs => ?)) "+
+				"RETURNING %s",
+			runScheduleIDColumn, runOccurrenceAtColumn, runClaimedAtColumn, runAttemptsColumn, run(runAttemptsColumn),
+			run(runMissionIDColumn), run(runFailedAtColumn), run(runClaimedAtColumn), run(runClaimedAtColumn),
+			runAttemptsColumn,
+		), staleAfter.Seconds()).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	claim := &Claim{Acquired: true}
+	err = r.db.QueryRow(ctx, query, args...).Scan(&claim.Attempts)
+	if err == nil {
+		return claim, nil
+	}
+
+	var pgErr *pgconn.PgError
+	if ok := errors.As(err, &pgErr); ok {
+		if pgErr.Code == "23503" {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrScheduleNotFound)
+		}
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// the claim is held by a run in progress or the occurrence is already settled
+	query, args, err = r.builder.
+		Select(
+			fmt.Sprintf("%s IS NOT NULL OR %s IS NOT NULL", runMissionIDColumn, runFailedAtColumn),
+			runAttemptsColumn,
+		).
+		From(runsTableName).
+		Where(sq.Eq{runScheduleIDColumn: scheduleID, runOccurrenceAtColumn: occurrence}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	claim = &Claim{}
+	err = r.db.QueryRow(ctx, query, args...).Scan(&claim.Settled, &claim.Attempts)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claim, nil
+}
+
+// FailOccurrence drops the claim of a run whose mission could not be created and keeps the reason.
+// The next run retries it unless giveUp settles the occurrence for good
+func (r *Repository) FailOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time, reason string, giveUp bool) error {
+	const op = "schedule.Repository.FailOccurrence"
+
+	builder := r.builder.
+		Update(runsTableName).
+		Set(runClaimedAtColumn, nil).
+		Set(runLastErrorColumn, reason).
+		Where(sq.Eq{runScheduleIDColumn: scheduleID, runOccurrenceAtColumn: occurrence}).
+		Where(sq.Eq{runMissionIDColumn: nil})
+
+	if giveUp {
+		builder = builder.Set(runFailedAtColumn, sq.Expr("now()"))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// FinishOccurrence links the created mission to the claimed occurrence and moves the schedule to its
+// next occurrence. A nil missionID only advances the schedule. The schedule is left untouched if it
+// was re-planned in the meantime
+func (r *Repository) FinishOccurrence(ctx context.Context, sch *Schedule, occurrence time.Time, missionID *uuid.UUID) error {
+	const op = "schedule.Repository.FinishOccurrence"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if missionID != nil {
+		query, args, err := r.builder.
+			Update(runsTableName).
+			Set(runMissionIDColumn, *missionID).
+			Where(sq.Eq{runScheduleIDColumn: sch.ID, runOccurrenceAtColumn: occurrence}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	query, args, err := r.builder.
+		Update(tableName).
+		Set(nextRunAtColumn, sch.NextRunAt).
+		Where(sq.Eq{idColumn: sch.ID, nextRunAtColumn: occurrence}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
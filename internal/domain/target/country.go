@@ -0,0 +1,172 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	countryReportTableName = "target_country_backfill_report"
+	reportTargetIDColumn   = "target_id"
+	reportMissionIDColumn  = "mission_id"
+	reportCountryColumn    = "country"
+	updatedAtTriggerName   = "update_targets_updated_at"
+)
+
+// CountryBackfill Unmatched targets are listed in the report table
+type CountryBackfill struct {
+	Normalized int
+	Unmatched  int
+}
+
+// BackfillCountries normalizes the stored countries to their alpha-2 code with country.Lookup, so it follows
+// the embedded dataset. Values nothing matches stay as they are and are listed in the report table for a manual
+// fix, a target fixed since is dropped from it. A normalized spelling is not an edit, so the updated_at trigger
+// is disabled inside each batch transaction
+func (r *Repository) BackfillCountries(ctx context.Context, batchSize int) (*CountryBackfill, error) {
+	const op = "target.Repository.BackfillCountries"
+	backfill := &CountryBackfill{}
+	lastID := uuid.Nil
+
+	for {
+		n, err := r.backfillCountriesBatch(ctx, &lastID, batchSize, backfill)
+		if err != nil {
+			return backfill, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if n < batchSize {
+			return backfill, nil
+		}
+	}
+}
+
+// backfillCountriesBatch walks the table by ID, lastID is moved past the batch
+func (r *Repository) backfillCountriesBatch(ctx context.Context, lastID *uuid.UUID, batchSize int, backfill *CountryBackfill) (int, error) {
+	type storedCountry struct {
+		id        uuid.UUID
+		missionID *uuid.UUID
+		country   string
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s DISABLE TRIGGER %s", tableName, updatedAtTriggerName)); err != nil {
+		return 0, err
+	}
+
+	query, args, err := r.builder.
+		Select(idColumn, missionIDColumn, countryColumn).
+		From(tableName).
+		Where(sq.Gt{idColumn: *lastID}).
+		OrderBy(idColumn).
+		Limit(uint64(batchSize)).
+		ToSql()
+
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	batch := make([]storedCountry, 0, batchSize)
+	for rows.Next() {
+		var s storedCountry
+		if err = rows.Scan(&s.id, &s.missionID, &s.country); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, s)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	matched := make([]uuid.UUID, 0, len(batch))
+	normalized, unmatched := 0, 0
+
+	for _, s := range batch {
+		c, ok := country.Lookup(s.country)
+		if !ok {
+			err = execInsert(ctx, tx, r.builder.
+				Insert(countryReportTableName).
+				Columns(reportTargetIDColumn, reportMissionIDColumn, reportCountryColumn).
+				Values(s.id, s.missionID, s.country).
+				Suffix("ON CONFLICT (target_id) DO UPDATE SET country = EXCLUDED.country, reported_at = now()"))
+			if err != nil {
+				return 0, err
+			}
+
+			unmatched++
+			continue
+		}
+
+		matched = append(matched, s.id)
+		if c.Alpha2 == s.country {
+			continue
+		}
+
+		err = execUpdate(ctx, tx, r.builder.
+			Update(tableName).
+			Set(countryColumn, c.Alpha2).
+			Where(sq.Eq{idColumn: s.id}))
+		if err != nil {
+			return 0, err
+		}
+
+		normalized++
+	}
+
+	if len(matched) > 0 {
+		query, args, err = r.builder.
+			Delete(countryReportTableName).
+			Where(sq.Expr(reportTargetIDColumn+" = ANY(?)", matched)).
+			ToSql()
+
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err = tx.Exec(ctx, query, args...); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s ENABLE TRIGGER %s", tableName, updatedAtTriggerName)); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	if len(batch) > 0 {
+		*lastID = batch[len(batch)-1].id
+	}
+	backfill.Normalized += normalized
+	backfill.Unmatched += unmatched
+
+	return len(batch), nil
+}
+
+func execInsert(ctx context.Context, tx pgx.Tx, insert sq.InsertBuilder) error {
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,109 @@
+package target
+
+import (
+	"github.com/google/uuid"
+	"strings"
+)
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// DiffLine is a single line of a line based diff, Op tells whether it is kept, added or removed
+type DiffLine struct {
+	Op   string
+	Text string
+}
+
+// NotesDiff describes how the notes changed from revision From to revision To
+type NotesDiff struct {
+	TargetID  uuid.UUID
+	From      int
+	To        int
+	Lines     []DiffLine
+	Inserted  int
+	Deleted   int
+	Unchanged int
+}
+
+// DiffLines computes a line diff out of the longest common subsequence of both texts,
+// notes are short enough for the quadratic table
+func DiffLines(from, to string) []DiffLine {
+	a, b := splitLines(from), splitLines(to)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		lines = append(lines, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+
+	for ; j < len(b); j++ {
+		lines = append(lines, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+
+	return lines
+}
+
+// NewNotesDiff diffs the content of two revisions of the same target
+func NewNotesDiff(from, to *NotesRevision) *NotesDiff {
+	diff := &NotesDiff{
+		TargetID: from.TargetID,
+		From:     from.Revision,
+		To:       to.Revision,
+		Lines:    DiffLines(from.Content, to.Content),
+	}
+
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case DiffInsert:
+			diff.Inserted++
+		case DiffDelete:
+			diff.Deleted++
+		default:
+			diff.Unchanged++
+		}
+	}
+
+	return diff
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
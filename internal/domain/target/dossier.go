@@ -0,0 +1,104 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// LinkDossier points the target at the dossier, a target already linked elsewhere is moved over
+func (r *Repository) LinkDossier(ctx context.Context, id, dossierID uuid.UUID) error {
+	const op = "target.Repository.LinkDossier"
+
+	query, args, err := r.builder.Update(tableName).
+		Set(dossierIDColumn, dossierID).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%s: %w", op, utils.ErrDossierNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) UnlinkDossier(ctx context.Context, id, dossierID uuid.UUID) error {
+	const op = "target.Repository.UnlinkDossier"
+
+	query, args, err := r.builder.Update(tableName).
+		Set(dossierIDColumn, nil).
+		Where(sq.Eq{idColumn: id, dossierIDColumn: dossierID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetNotLinked)
+	}
+
+	return nil
+}
+
+// GetTargetsByDossierID lists the linked targets of all missions, the oldest first
+func (r *Repository) GetTargetsByDossierID(ctx context.Context, dossierID uuid.UUID) ([]*Target, error) {
+	const op = "target.Repository.GetTargetsByDossierID"
+	targets := make([]*Target, 0)
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{dossierIDColumn: dossierID}).
+		OrderBy(createdAtColumn, idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var target Target
+
+		if err = r.scanTarget(rows, &target); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		targets = append(targets, &target)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,314 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+)
+
+// SealedNotes is the stored form of target notes, Index is the blind index their search goes through
+type SealedNotes struct {
+	Value string
+	KeyID string
+	Index []string
+}
+
+// ReencryptOptions Decrypt writes the values back in plain text, which is needed before rolling the encryption
+// migrations back. Reindex rewrites every row instead of only the ones not sealed with the active key, it is
+// needed after the index key changed
+type ReencryptOptions struct {
+	Decrypt   bool
+	Reindex   bool
+	BatchSize int
+}
+
+// InitialNotes is the stored form of the notes of a new target together with its first revision
+type InitialNotes struct {
+	Notes         SealedNotes
+	Revision      string
+	RevisionKeyID string
+}
+
+// SealInitialNotes seals the notes of a new target and its first revision. Sealed notes only open for the target
+// they were sealed for, so notes copied to another target are sealed again with the ID of the new one
+func (r *Repository) SealInitialNotes(targetID uuid.UUID, notes string) (*InitialNotes, error) {
+	sealed, err := r.sealNotes(targetID, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, revisionKeyID, err := r.sealRevision(targetID, firstRevision, notes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InitialNotes{Notes: *sealed, Revision: revision, RevisionKeyID: revisionKeyID}, nil
+}
+
+func (r *Repository) sealNotes(targetID uuid.UUID, notes string) (*SealedNotes, error) {
+	value, keyID, err := r.keys.Seal(notes, notesBinding(targetID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SealedNotes{Value: value, KeyID: keyID, Index: r.keys.BlindIndex(encryption.Terms(notes))}, nil
+}
+
+func (r *Repository) sealRevision(targetID uuid.UUID, revision int, content string) (string, string, error) {
+	return r.keys.Seal(content, revisionBinding(targetID, revision))
+}
+
+// openSealed values without a key ID were stored before notes were encrypted and are returned as they are
+func (r *Repository) openSealed(value string, keyID *string, binding []byte) (string, error) {
+	if keyID == nil {
+		return value, nil
+	}
+
+	return r.keys.Open(value, *keyID, binding)
+}
+
+func notesBinding(targetID uuid.UUID) []byte {
+	return []byte(tableName + "/" + targetID.String())
+}
+
+func revisionBinding(targetID uuid.UUID, revision int) []byte {
+	return []byte(revisionsTableName + "/" + targetID.String() + "/" + strconv.Itoa(revision))
+}
+
+// ReencryptNotes seals the notes of targets and their revisions with the active key, including the ones still
+// stored in plain text, and rebuilds the blind index of the notes. Rows are processed in
+// batches, each in its own transaction; rows locked by a concurrent write are skipped since that write
+// seals them with the active key anyway
+func (r *Repository) ReencryptNotes(ctx context.Context, opts ReencryptOptions) (int, error) {
+	const op = "target.Repository.ReencryptNotes"
+
+	total := 0
+	for _, batch := range []func(context.Context, ReencryptOptions) func() (int, error){
+		r.reencryptTargets,
+		r.reencryptRevisions,
+	} {
+		n, err := reencryptAll(opts.BatchSize, batch(ctx, opts))
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return total, nil
+}
+
+func reencryptAll(batchSize int, batch func() (int, error)) (int, error) {
+	total := 0
+
+	for {
+		n, err := batch()
+		total += n
+		if err != nil || n < batchSize {
+			return total, err
+		}
+	}
+}
+
+// reencryptTargets returns the next batch to run on every call, the batches walk the table by ID
+func (r *Repository) reencryptTargets(ctx context.Context, opts ReencryptOptions) func() (int, error) {
+	lastID := uuid.Nil
+
+	return func() (int, error) {
+		type pendingNotes struct {
+			id    uuid.UUID
+			notes string
+			keyID *string
+		}
+
+		tx, err := r.db.Begin(ctx)
+		if err != nil {
+			return 0, err
+		}
+		defer tx.Rollback(ctx)
+
+		query, args, err := r.builder.
+			Select(idColumn, notesColumn, notesKeyIDColumn).
+			From(tableName).
+			Where(r.pendingKey(notesKeyIDColumn, opts)).
+			Where(sq.Gt{idColumn: lastID}).
+			OrderBy(idColumn).
+			Limit(uint64(opts.BatchSize)).
+			Suffix("FOR UPDATE SKIP LOCKED").
+			ToSql()
+
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		batch := make([]pendingNotes, 0, opts.BatchSize)
+		for rows.Next() {
+			var p pendingNotes
+			if err = rows.Scan(&p.id, &p.notes, &p.keyID); err != nil {
+				rows.Close()
+				return 0, err
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return 0, err
+		}
+
+		for _, p := range batch {
+			notes, err := r.openSealed(p.notes, p.keyID, notesBinding(p.id))
+			if err != nil {
+				return 0, fmt.Errorf("target %s: %w", p.id, err)
+			}
+
+			update := r.builder.Update(tableName).Where(sq.Eq{idColumn: p.id})
+			if opts.Decrypt {
+				update = update.Set(notesColumn, notes).Set(notesKeyIDColumn, nil)
+			} else {
+				sealed, err := r.sealNotes(p.id, notes)
+				if err != nil {
+					return 0, err
+				}
+
+				update = update.
+					Set(notesColumn, sealed.Value).
+					Set(notesKeyIDColumn, sealed.KeyID).
+					Set(notesIndexColumn, sealed.Index)
+			}
+
+			if err = execUpdate(ctx, tx, update); err != nil {
+				return 0, err
+			}
+		}
+
+		if err = tx.Commit(ctx); err != nil {
+			return 0, err
+		}
+
+		if len(batch) > 0 {
+			lastID = batch[len(batch)-1].id
+		}
+
+		return len(batch), nil
+	}
+}
+
+func (r *Repository) reencryptRevisions(ctx context.Context, opts ReencryptOptions) func() (int, error) {
+	lastTargetID, lastRevision := uuid.Nil, 0
+
+	return func() (int, error) {
+		type pendingContent struct {
+			targetID uuid.UUID
+			revision int
+			content  string
+			keyID    *string
+		}
+
+		tx, err := r.db.Begin(ctx)
+		if err != nil {
+			return 0, err
+		}
+		defer tx.Rollback(ctx)
+
+		query, args, err := r.builder.
+			Select(revTargetIDColumn, revRevisionColumn, revContentColumn, revKeyIDColumn).
+			From(revisionsTableName).
+			Where(r.pendingKey(revKeyIDColumn, opts)).
+			Where(sq.Expr("("+revTargetIDColumn+", "+revRevisionColumn+") > (?, ?)", lastTargetID, lastRevision)).
+			OrderBy(revTargetIDColumn, revRevisionColumn).
+			Limit(uint64(opts.BatchSize)).
+			Suffix("FOR UPDATE SKIP LOCKED").
+			ToSql()
+
+		if err != nil {
+			return 0, err
+		}
+
+		rows, err := tx.Query(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		batch := make([]pendingContent, 0, opts.BatchSize)
+		for rows.Next() {
+			var p pendingContent
+			if err = rows.Scan(&p.targetID, &p.revision, &p.content, &p.keyID); err != nil {
+				rows.Close()
+				return 0, err
+			}
+			batch = append(batch, p)
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return 0, err
+		}
+
+		for _, p := range batch {
+			content, err := r.openSealed(p.content, p.keyID, revisionBinding(p.targetID, p.revision))
+			if err != nil {
+				return 0, fmt.Errorf("target %s revision %d: %w", p.targetID, p.revision, err)
+			}
+
+			var keyID *string
+			if !opts.Decrypt {
+				sealed, activeID, err := r.sealRevision(p.targetID, p.revision, content)
+				if err != nil {
+					return 0, err
+				}
+				content, keyID = sealed, &activeID
+			}
+
+			update := r.builder.Update(revisionsTableName).
+				Set(revContentColumn, content).
+				Set(revKeyIDColumn, keyID).
+				Where(sq.Eq{revTargetIDColumn: p.targetID, revRevisionColumn: p.revision})
+
+			if err = execUpdate(ctx, tx, update); err != nil {
+				return 0, err
+			}
+		}
+
+		if err = tx.Commit(ctx); err != nil {
+			return 0, err
+		}
+
+		if len(batch) > 0 {
+			lastTargetID, lastRevision = batch[len(batch)-1].targetID, batch[len(batch)-1].revision
+		}
+
+		return len(batch), nil
+	}
+}
+
+// pendingKey matches the rows the re-encryption still has to touch: encrypted ones when decrypting, every row
+// when reindexing, otherwise the plain ones and the ones sealed with another key than the active one
+func (r *Repository) pendingKey(column string, opts ReencryptOptions) sq.Sqlizer {
+	switch {
+	case opts.Decrypt:
+		return sq.NotEq{column: nil}
+	case opts.Reindex:
+		return sq.And{}
+	default:
+		return sq.Or{sq.Eq{column: nil}, sq.NotEq{column: r.keys.ActiveKeyID()}}
+	}
+}
+
+func execUpdate(ctx context.Context, tx pgx.Tx, update sq.UpdateBuilder) error {
+	query, args, err := update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
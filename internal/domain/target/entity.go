@@ -2,26 +2,44 @@ package target
 
 import (
 	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"time"
 )
 
 type Target struct {
-	ID        uuid.UUID
-	Name      string `validate:"required"`
-	Country   string `validate:"required"`
-	Notes     string
-	State     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uuid.UUID
+	MissionID    uuid.UUID
+	Name         string `validate:"required"`
+	Country      string `validate:"required"`
+	CountryName  string
+	Notes        string
+	State        string
+	CompletedAt  *time.Time
+	LastPosition *Position
+	DossierID    *uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
-func NewEntity(name, country string, notes string) *Target {
+// Position is the last known location of a target, taken from its latest sighting
+type Position struct {
+	Latitude   float64
+	Longitude  float64
+	ObservedAt time.Time
+}
+
+// NewEntity : the country is stored as its ISO 3166-1 alpha-2 code when it can be recognized
+func NewEntity(name, countryValue string, notes string) *Target {
+	code := country.Code(countryValue)
+
 	return &Target{
-		Name:    name,
-		Country: country,
-		Notes:   notes,
+		Name:        name,
+		Country:     code,
+		CountryName: country.DisplayName(code),
+		Notes:       notes,
 	}
 }
 
@@ -34,5 +52,9 @@ func (t *Target) Validate() error {
 		return fmt.Errorf("%s: %s", op, err.Error())
 	}
 
+	if !country.IsCode(t.Country) {
+		return fmt.Errorf("%s: %w", op, utils.ErrUnknownCountry)
+	}
+
 	return nil
 }
@@ -0,0 +1,277 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strings"
+	"time"
+)
+
+const (
+	entriesTableName      = "target_journal_entries"
+	entryIDColumn         = "id"
+	entryTargetIDColumn   = "target_id"
+	entryAuthorColumn     = "author"
+	entryBodyColumn       = "body"
+	entryLocationColumn   = "location"
+	entryConfidenceColumn = "confidence"
+	entryObservedAtColumn = "observed_at"
+	entryCreatedAtColumn  = "created_at"
+	entryUpdatedAtColumn  = "updated_at"
+)
+
+const (
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+
+	summaryTimeLayout = "2006-01-02 15:04 MST"
+)
+
+var entryColumns = []string{
+	entryIDColumn,
+	entryTargetIDColumn,
+	entryAuthorColumn,
+	entryBodyColumn,
+	entryLocationColumn,
+	entryConfidenceColumn,
+	entryObservedAtColumn,
+	entryCreatedAtColumn,
+	entryUpdatedAtColumn,
+}
+
+// JournalEntry is a single field observation on a target, entries are never removed
+type JournalEntry struct {
+	ID         uuid.UUID
+	TargetID   uuid.UUID
+	Author     string  `validate:"required,max=100"`
+	Body       string  `validate:"required,max=4000"`
+	Location   *string `validate:"omitempty,max=200"`
+	Confidence string  `validate:"oneof=low medium high"`
+	ObservedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CreateJournalEntrySvc struct {
+	Body       string
+	Location   *string
+	Confidence string
+	ObservedAt *time.Time
+}
+
+// UpdateJournalEntryParams only the set fields are changed
+type UpdateJournalEntryParams struct {
+	Body       *string
+	Location   *string
+	Confidence *string
+	ObservedAt *time.Time
+}
+
+// NewJournalEntry : confidence defaults to medium and the observation time to now
+func NewJournalEntry(targetID uuid.UUID, author string, req CreateJournalEntrySvc, now time.Time) *JournalEntry {
+	entry := &JournalEntry{
+		TargetID:   targetID,
+		Author:     author,
+		Body:       strings.TrimSpace(req.Body),
+		Location:   req.Location,
+		Confidence: strings.ToLower(req.Confidence),
+		ObservedAt: now,
+	}
+
+	if entry.Confidence == "" {
+		entry.Confidence = ConfidenceMedium
+	}
+
+	if req.ObservedAt != nil {
+		entry.ObservedAt = *req.ObservedAt
+	}
+
+	return entry
+}
+
+func (e *JournalEntry) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(e); err != nil {
+		return utils.ErrInvalidEntry
+	}
+
+	return nil
+}
+
+func (e *JournalEntry) Update(params UpdateJournalEntryParams) {
+	if params.Body != nil {
+		e.Body = strings.TrimSpace(*params.Body)
+	}
+
+	if params.Location != nil {
+		e.Location = params.Location
+	}
+
+	if params.Confidence != nil {
+		e.Confidence = strings.ToLower(*params.Confidence)
+	}
+
+	if params.ObservedAt != nil {
+		e.ObservedAt = *params.ObservedAt
+	}
+}
+
+// Summarize renders the entries as notes, one line per observation in the order they were observed
+func Summarize(entries []*JournalEntry) string {
+	lines := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		line := fmt.Sprintf("%s [%s]", entry.ObservedAt.UTC().Format(summaryTimeLayout), entry.Confidence)
+		if entry.Location != nil && *entry.Location != "" {
+			line += " @ " + *entry.Location
+		}
+
+		line += fmt.Sprintf(": %s (%s)", strings.ReplaceAll(entry.Body, "\n", " "), entry.Author)
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func scanEntry(row pgx.Row, entry *JournalEntry) error {
+	return row.Scan(
+		&entry.ID,
+		&entry.TargetID,
+		&entry.Author,
+		&entry.Body,
+		&entry.Location,
+		&entry.Confidence,
+		&entry.ObservedAt,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+}
+
+func (r *Repository) AddJournalEntry(ctx context.Context, entry *JournalEntry) (uuid.UUID, error) {
+	const op = "target.Repository.AddJournalEntry"
+	var id uuid.UUID
+
+	query, args, err := r.builder.
+		Insert(entriesTableName).
+		Columns(entryTargetIDColumn, entryAuthorColumn, entryBodyColumn, entryLocationColumn, entryConfidenceColumn, entryObservedAtColumn).
+		Values(entry.TargetID, entry.Author, entry.Body, entry.Location, entry.Confidence, entry.ObservedAt).
+		Suffix("RETURNING " + entryIDColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.db.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetJournalEntries lists the target entries in the order they were observed, a target of another mission is reported as not found
+func (r *Repository) GetJournalEntries(ctx context.Context, missionID, targetID uuid.UUID) ([]*JournalEntry, error) {
+	const op = "target.Repository.GetJournalEntries"
+	entries := make([]*JournalEntry, 0)
+
+	query, args, err := r.builder.
+		Select(entryColumns...).
+		From(entriesTableName).
+		Where(inMission(entryTargetIDColumn, missionID, targetID)).
+		OrderBy(entryObservedAtColumn, entryCreatedAtColumn, entryIDColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry JournalEntry
+
+		if err = scanEntry(rows, &entry); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(entries) == 0 {
+		if err = r.checkMissionTarget(ctx, missionID, targetID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return entries, nil
+}
+
+func (r *Repository) GetJournalEntry(ctx context.Context, targetID, id uuid.UUID) (*JournalEntry, error) {
+	const op = "target.Repository.GetJournalEntry"
+	var entry JournalEntry
+
+	query, args, err := r.builder.
+		Select(entryColumns...).
+		From(entriesTableName).
+		Where(sq.Eq{entryIDColumn: id, entryTargetIDColumn: targetID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = scanEntry(r.db.QueryRow(ctx, query, args...), &entry); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrEntryNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &entry, nil
+}
+
+// UpdateJournalEntry rewrites the entry only while its target is open,
+// the check is part of the statement so a concurrent completion is not lost
+func (r *Repository) UpdateJournalEntry(ctx context.Context, entry *JournalEntry) error {
+	const op = "target.Repository.UpdateJournalEntry"
+
+	query, args, err := r.builder.Update(entriesTableName).
+		Set(entryBodyColumn, entry.Body).
+		Set(entryLocationColumn, entry.Location).
+		Set(entryConfidenceColumn, entry.Confidence).
+		Set(entryObservedAtColumn, entry.ObservedAt).
+		Where(sq.Eq{entryIDColumn: entry.ID, entryTargetIDColumn: entry.TargetID}).
+		Where(sq.Expr("EXISTS (?)", sq.Select("1").From(tableName).Where(sq.Eq{idColumn: entry.TargetID, stateColumn: OpenStates}))).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetCompleted)
+	}
+
+	return nil
+}
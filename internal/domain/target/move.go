@@ -0,0 +1,209 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	missionsTableName      = "missions"
+	missionIDKeyColumn     = "id"
+	missionStateColumn     = "state"
+	missionDeletedAtColumn = "deleted_at"
+	missionCompletedState  = "completed"
+	missionAbortedState    = "aborted"
+)
+
+// MoveHook runs inside the move transaction once the target moved, countries holds the target countries
+// of both missions after the move. The move is rolled back when it fails
+type MoveHook func(ctx context.Context, tx pgx.Tx, countries map[uuid.UUID][]string) error
+
+// MoveTarget reassigns an open target to another mission. Both missions are locked in id order,
+// so two opposite moves can not deadlock, and the limits are checked against the locked rows
+func (r *Repository) MoveTarget(ctx context.Context, id, fromMissionID, toMissionID uuid.UUID, limit int, hook MoveHook) error {
+	const op = "target.Repository.MoveTarget"
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	lockQuery, lockArgs, err := r.builder.
+		Select(missionIDKeyColumn, missionStateColumn).
+		From(missionsTableName).
+		Where(sq.Expr(missionIDKeyColumn+" = ANY(?)", []uuid.UUID{fromMissionID, toMissionID})).
+		Where(sq.Eq{missionDeletedAtColumn: nil}).
+		OrderBy(missionIDKeyColumn).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := tx.Query(ctx, lockQuery, lockArgs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	states := make(map[uuid.UUID]string, 2)
+	for rows.Next() {
+		var (
+			missionID uuid.UUID
+			state     string
+		)
+
+		if err = rows.Scan(&missionID, &state); err != nil {
+			rows.Close()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		states[missionID] = state
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, missionID := range []uuid.UUID{fromMissionID, toMissionID} {
+		state, ok := states[missionID]
+		switch {
+		case !ok:
+			return fmt.Errorf("%s: %w", op, utils.ErrMissionNotFound)
+		case state == missionCompletedState:
+			return fmt.Errorf("%s: %w", op, utils.ErrMissionCompleted)
+		case state == missionAbortedState:
+			return fmt.Errorf("%s: %w", op, utils.ErrMissionAborted)
+		}
+	}
+
+	stateQuery, stateArgs, err := r.builder.
+		Select(stateColumn).
+		From(tableName).
+		Where(sq.Eq{idColumn: id, missionIDColumn: fromMissionID}).
+		Suffix("FOR UPDATE").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var state string
+	if err = tx.QueryRow(ctx, stateQuery, stateArgs...).Scan(&state); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = checkStateEditable(state); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	countQuery, countArgs, err := r.builder.
+		Select(missionIDColumn, "COUNT(*)").
+		From(tableName).
+		Where(sq.Expr(missionIDColumn+" = ANY(?)", []uuid.UUID{fromMissionID, toMissionID})).
+		GroupBy(missionIDColumn).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = tx.Query(ctx, countQuery, countArgs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	counts := make(map[uuid.UUID]int, 2)
+	for rows.Next() {
+		var (
+			missionID uuid.UUID
+			count     int
+		)
+
+		if err = rows.Scan(&missionID, &count); err != nil {
+			rows.Close()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		counts[missionID] = count
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if counts[fromMissionID] <= 1 {
+		return fmt.Errorf("%s: %w", op, utils.ErrLastTarget)
+	}
+
+	if counts[toMissionID] >= limit {
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetOverflow)
+	}
+
+	moveQuery, moveArgs, err := r.builder.
+		Update(tableName).
+		Set(missionIDColumn, toMissionID).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, moveQuery, moveArgs...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	countriesQuery, countriesArgs, err := r.builder.
+		Select(missionIDColumn, countryColumn).
+		From(tableName).
+		Where(sq.Expr(missionIDColumn+" = ANY(?)", []uuid.UUID{fromMissionID, toMissionID})).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err = tx.Query(ctx, countriesQuery, countriesArgs...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	countries := make(map[uuid.UUID][]string, 2)
+	for rows.Next() {
+		var (
+			missionID uuid.UUID
+			country   string
+		)
+
+		if err = rows.Scan(&missionID, &country); err != nil {
+			rows.Close()
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		countries[missionID] = append(countries[missionID], country)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = hook(ctx, tx, countries); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
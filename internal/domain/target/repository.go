@@ -5,66 +5,165 @@ import (
 	"errors"
 	"fmt"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 )
 
 type Repository struct {
 	db      *pgxpool.Pool
 	builder sq.StatementBuilderType
+	keys    *encryption.Keyring
 }
 
 const (
-	tableName       = "targets"
-	idColumn        = "id"
-	missionIDColumn = "mission_id"
-	nameColumn      = "name"
-	countryColumn   = "country"
-	notesColumn     = "notes"
-	stateColumn     = "state"
-	createdAtColumn = "created_at"
-	updatedAtColumn = "updated_at"
-	completedState  = "completed"
+	tableName         = "targets"
+	idColumn          = "id"
+	missionIDColumn   = "mission_id"
+	nameColumn        = "name"
+	countryColumn     = "country"
+	notesColumn       = "notes"
+	notesKeyIDColumn  = "notes_key_id"
+	notesIndexColumn  = "notes_index"
+	stateColumn       = "state"
+	completedAtColumn = "completed_at"
+	lastLatColumn     = "last_latitude"
+	lastLngColumn     = "last_longitude"
+	lastSeenAtColumn  = "last_seen_at"
+	dossierIDColumn   = "dossier_id"
+	createdAtColumn   = "created_at"
+	updatedAtColumn   = "updated_at"
 )
 
-func NewRepository(pool *pgxpool.Pool) *Repository {
+var selectColumns = []string{
+	idColumn,
+	missionIDColumn,
+	nameColumn,
+	countryColumn,
+	notesColumn,
+	notesKeyIDColumn,
+	stateColumn,
+	completedAtColumn,
+	lastLatColumn,
+	lastLngColumn,
+	lastSeenAtColumn,
+	dossierIDColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+// scanTarget decrypts the notes, notes without a key ID were written before encryption and are read as they are
+func (r *Repository) scanTarget(row pgx.Row, target *Target) error {
+	var (
+		notesKeyID       *string
+		lastLat, lastLng *float64
+		lastSeenAt       *time.Time
+	)
+
+	err := row.Scan(
+		&target.ID,
+		&target.MissionID,
+		&target.Name,
+		&target.Country,
+		&target.Notes,
+		&notesKeyID,
+		&target.State,
+		&target.CompletedAt,
+		&lastLat,
+		&lastLng,
+		&lastSeenAt,
+		&target.DossierID,
+		&target.CreatedAt,
+		&target.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if target.Notes, err = r.openSealed(target.Notes, notesKeyID, notesBinding(target.ID)); err != nil {
+		return err
+	}
+
+	target.CountryName = country.DisplayName(target.Country)
+
+	if lastLat != nil && lastLng != nil && lastSeenAt != nil {
+		target.LastPosition = &Position{Latitude: *lastLat, Longitude: *lastLng, ObservedAt: *lastSeenAt}
+	}
+
+	return nil
+}
+
+func NewRepository(pool *pgxpool.Pool, keys *encryption.Keyring) *Repository {
 	builder := sq.StatementBuilderType{}
 	builder = builder.PlaceholderFormat(sq.Dollar)
-	return &Repository{db: pool, builder: builder}
+	return &Repository{db: pool, builder: builder, keys: keys}
 }
 
-func (r *Repository) AddTarget(ctx context.Context, missionID uuid.UUID, target *Target) (uuid.UUID, error) {
+// AddTarget stores the initial notes of the target as its first revision. The ID is generated upfront
+// since the sealed notes are bound to it
+func (r *Repository) AddTarget(ctx context.Context, missionID uuid.UUID, target *Target, author string) (uuid.UUID, error) {
 	const op = "target.Repository.AddTarget"
-	var id uuid.UUID
+	id := uuid.New()
+
+	notes, err := r.SealInitialNotes(id, target.Notes)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
 
 	query, args, err := r.builder.
 		Insert(tableName).
-		Columns(missionIDColumn, nameColumn, countryColumn, notesColumn).
-		Values(missionID, target.Name, target.Country, target.Notes).
-		Suffix("RETURNING " + idColumn).
+		Columns(idColumn, missionIDColumn, nameColumn, countryColumn, notesColumn, notesKeyIDColumn, notesIndexColumn).
+		Values(id, missionID, target.Name, target.Country, notes.Notes.Value, notes.Notes.KeyID, notes.Notes.Index).
 		ToSql()
 
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = r.db.QueryRow(ctx, query, args...).Scan(&id)
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err = r.builder.
+		Insert(revisionsTableName).
+		Columns(revTargetIDColumn, revRevisionColumn, revAuthorColumn, revContentColumn, revKeyIDColumn).
+		Values(id, firstRevision, author, notes.Revision, notes.RevisionKeyID).
+		ToSql()
+
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return id, nil
 }
 
-func (r *Repository) SetTargetCompleted(ctx context.Context, id uuid.UUID) error {
-	const op = "target.Repository.SetTargetCompleted"
+// UpdateTargetState applies a transition made on the entity, it fails when the target left fromState meanwhile
+func (r *Repository) UpdateTargetState(ctx context.Context, target *Target, fromState string) error {
+	const op = "target.Repository.UpdateTargetState"
 
 	query, args, err := r.builder.Update(tableName).
-		Set(stateColumn, completedState).
-		Where(sq.Eq{idColumn: id}).
+		Set(stateColumn, target.State).
+		Set(completedAtColumn, target.CompletedAt).
+		Where(sq.Eq{idColumn: target.ID, missionIDColumn: target.MissionID, stateColumn: fromState}).
 		ToSql()
 
 	if err != nil {
@@ -78,42 +177,96 @@ func (r *Repository) SetTargetCompleted(ctx context.Context, id uuid.UUID) error
 	}
 
 	if res.RowsAffected() == 0 {
-		return fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetTransition)
 	}
 
 	return nil
 }
 
-func (r *Repository) UpdateTargetNotes(ctx context.Context, id uuid.UUID, notes string) error {
+// UpdateTargetNotes stores the notes as a new revision and returns its number.
+// The target row is locked so concurrent updates get consecutive revision numbers
+func (r *Repository) UpdateTargetNotes(ctx context.Context, missionID, id uuid.UUID, notes, author string) (int, error) {
 	const op = "target.Repository.UpdateTargetNotes"
+	var revision int
+
+	sealed, err := r.sealNotes(id, notes)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
 
 	query, args, err := r.builder.Update(tableName).
-		Set(notesColumn, notes).
-		Where(sq.Eq{idColumn: id}).
+		Set(notesColumn, sealed.Value).
+		Set(notesKeyIDColumn, sealed.KeyID).
+		Set(notesIndexColumn, sealed.Index).
+		Where(sq.Eq{idColumn: id, missionIDColumn: missionID}).
 		ToSql()
 
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	var res pgconn.CommandTag
-	res, err = r.db.Exec(ctx, query, args...)
+	res, err = tx.Exec(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if res.RowsAffected() == 0 {
-		return fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+		return 0, fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
 	}
 
-	return nil
+	// the content is bound to its revision number, so the number is taken before sealing
+	query, args, err = r.builder.
+		Select("COALESCE(MAX(" + revRevisionColumn + "), 0) + 1").
+		From(revisionsTableName).
+		Where(sq.Eq{revTargetIDColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&revision); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	content, contentKeyID, err := r.sealRevision(id, revision, notes)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err = r.builder.
+		Insert(revisionsTableName).
+		Columns(revTargetIDColumn, revRevisionColumn, revAuthorColumn, revContentColumn, revKeyIDColumn).
+		Values(id, revision, author, content, contentKeyID).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revision, nil
 }
 
 func (r *Repository) GetTargetsByMissionID(ctx context.Context, missionID uuid.UUID) ([]*Target, error) {
 	const op = "target.Repository.GetTargetsByMissionID"
 
 	query, args, err := r.builder.
-		Select(idColumn, nameColumn, countryColumn, notesColumn, stateColumn, createdAtColumn, updatedAtColumn).
+		Select(selectColumns...).
 		From(tableName).
 		Where(sq.Eq{missionIDColumn: missionID}).
 		ToSql()
@@ -137,7 +290,7 @@ func (r *Repository) GetTargetsByMissionID(ctx context.Context, missionID uuid.U
 	for rows.Next() {
 		var target Target
 
-		err = rows.Scan(&target.ID, &target.Name, &target.Country, &target.Notes, &target.State, &target.CreatedAt, &target.UpdatedAt)
+		err = r.scanTarget(rows, &target)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -152,29 +305,105 @@ func (r *Repository) GetTargetsByMissionID(ctx context.Context, missionID uuid.U
 	return targets, nil
 }
 
+// GetTargetsByMissionIDs fetches the targets of all given missions in one query, grouped by mission ID
+func (r *Repository) GetTargetsByMissionIDs(ctx context.Context, missionIDs []uuid.UUID) (map[uuid.UUID][]*Target, error) {
+	const op = "target.Repository.GetTargetsByMissionIDs"
+	targets := make(map[uuid.UUID][]*Target, len(missionIDs))
+
+	if len(missionIDs) == 0 {
+		return targets, nil
+	}
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Expr(missionIDColumn+" = ANY(?)", missionIDs)).
+		OrderBy(createdAtColumn, idColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var target Target
+
+		if err = r.scanTarget(rows, &target); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		targets[target.MissionID] = append(targets[target.MissionID], &target)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targets, nil
+}
+
 func (r *Repository) GetTargetByID(ctx context.Context, id uuid.UUID) (*Target, error) {
-	const op = "target.Repository.GetTargetByID"
+	return r.getTarget(ctx, "target.Repository.GetTargetByID", sq.Eq{idColumn: id})
+}
+
+// GetMissionTarget a target of another mission is reported as not found
+func (r *Repository) GetMissionTarget(ctx context.Context, missionID, id uuid.UUID) (*Target, error) {
+	return r.getTarget(ctx, "target.Repository.GetMissionTarget", sq.Eq{idColumn: id, missionIDColumn: missionID})
+}
+
+// inMission restricts rows keyed by a target id to a target of the mission
+func inMission(targetIDColumn string, missionID, targetID uuid.UUID) sq.Sqlizer {
+	return sq.And{
+		sq.Eq{targetIDColumn: targetID},
+		sq.Expr("EXISTS (?)", sq.Select("1").From(tableName).Where(sq.Eq{idColumn: targetID, missionIDColumn: missionID})),
+	}
+}
+
+// checkMissionTarget tells an empty result of an inMission query apart from a target of another mission
+func (r *Repository) checkMissionTarget(ctx context.Context, missionID, id uuid.UUID) error {
+	var count int
+
+	query, args, err := r.builder.
+		Select("COUNT(*)").
+		From(tableName).
+		Where(sq.Eq{idColumn: id, missionIDColumn: missionID}).
+		ToSql()
+
+	if err != nil {
+		return err
+	}
+
+	if err = r.db.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return utils.ErrTargetNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) getTarget(ctx context.Context, op string, pred sq.Sqlizer) (*Target, error) {
 	var target Target
 
 	query, args, err := r.builder.
-		Select(idColumn, nameColumn, countryColumn, notesColumn, stateColumn, createdAtColumn, updatedAtColumn).
+		Select(selectColumns...).
 		From(tableName).
-		Where(sq.Eq{idColumn: id}).
+		Where(pred).
 		ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = r.db.QueryRow(ctx, query, args...).Scan(
-		&target.ID,
-		&target.Name,
-		&target.Country,
-		&target.Notes,
-		&target.State,
-		&target.CreatedAt,
-		&target.UpdatedAt,
-	)
+	err = r.scanTarget(r.db.QueryRow(ctx, query, args...), &target)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
@@ -186,20 +415,22 @@ func (r *Repository) GetTargetByID(ctx context.Context, id uuid.UUID) (*Target,
 	return &target, nil
 }
 
-func (r *Repository) DeleteTarget(ctx context.Context, id uuid.UUID) error {
+func (r *Repository) DeleteTarget(ctx context.Context, missionID, id uuid.UUID) error {
 	const op = "target.Repository.DeleteTarget"
 
-	query, args, err := r.builder.Delete(tableName).Where(sq.Eq{idColumn: id}).ToSql()
+	query, args, err := r.builder.Delete(tableName).Where(sq.Eq{idColumn: id, missionIDColumn: missionID}).ToSql()
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if _, err = r.db.Exec(ctx, query, args...); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return utils.ErrTargetNotFound
-		}
+	res, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+	}
+
 	return nil
 }
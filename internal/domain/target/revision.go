@@ -0,0 +1,136 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+const (
+	revisionsTableName = "target_notes_revisions"
+	revTargetIDColumn  = "target_id"
+	revRevisionColumn  = "revision"
+	revAuthorColumn    = "author"
+	revContentColumn   = "content"
+	revKeyIDColumn     = "content_key_id"
+	revCreatedAtColumn = "created_at"
+	firstRevision      = 1
+)
+
+var revisionColumns = []string{
+	revTargetIDColumn,
+	revRevisionColumn,
+	revAuthorColumn,
+	revContentColumn,
+	revKeyIDColumn,
+	revCreatedAtColumn,
+}
+
+// NotesRevision is one version of the target notes, revisions are numbered from 1 per target
+type NotesRevision struct {
+	TargetID  uuid.UUID
+	Revision  int
+	Author    string
+	Content   string
+	CreatedAt time.Time
+}
+
+func (r *Repository) scanRevision(row pgx.Row, revision *NotesRevision) error {
+	var contentKeyID *string
+
+	err := row.Scan(
+		&revision.TargetID,
+		&revision.Revision,
+		&revision.Author,
+		&revision.Content,
+		&contentKeyID,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	revision.Content, err = r.openSealed(revision.Content, contentKeyID, revisionBinding(revision.TargetID, revision.Revision))
+	return err
+}
+
+// GetNotesRevisions a target of another mission is reported as not found
+func (r *Repository) GetNotesRevisions(ctx context.Context, missionID, targetID uuid.UUID) ([]*NotesRevision, error) {
+	const op = "target.Repository.GetNotesRevisions"
+	revisions := make([]*NotesRevision, 0)
+
+	query, args, err := r.builder.
+		Select(revisionColumns...).
+		From(revisionsTableName).
+		Where(inMission(revTargetIDColumn, missionID, targetID)).
+		OrderBy(revRevisionColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var revision NotesRevision
+
+		if err = r.scanRevision(rows, &revision); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		revisions = append(revisions, &revision)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(revisions) == 0 {
+		if err = r.checkMissionTarget(ctx, missionID, targetID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return revisions, nil
+}
+
+// GetNotesRevision a target of another mission is reported as not found
+func (r *Repository) GetNotesRevision(ctx context.Context, missionID, targetID uuid.UUID, revision int) (*NotesRevision, error) {
+	const op = "target.Repository.GetNotesRevision"
+	var rev NotesRevision
+
+	query, args, err := r.builder.
+		Select(revisionColumns...).
+		From(revisionsTableName).
+		Where(inMission(revTargetIDColumn, missionID, targetID)).
+		Where(sq.Eq{revRevisionColumn: revision}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = r.scanRevision(r.db.QueryRow(ctx, query, args...), &rev); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if err = r.checkMissionTarget(ctx, missionID, targetID); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrRevisionNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &rev, nil
+}
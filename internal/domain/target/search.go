@@ -0,0 +1,218 @@
+package target
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"strings"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+
+	// headlineOptions matched words are wrapped in <mark>, long texts are cut to the best fragments
+	headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=25, MinWords=8, MaxFragments=2, FragmentDelimiter=\" … \""
+
+	// headlineWords and headlineContext shape the headlines of decrypted notes which are cut around the first match
+	headlineWords   = 25
+	headlineContext = 8
+
+	// notesMatchRank is added for notes matched through the blind index, which can't be ranked by ts_rank
+	notesMatchRank = 0.05
+)
+
+type SearchSpec struct {
+	Query string
+	Limit int
+}
+
+// SearchResult MatchedIn is notes, journal or name, the snippet is taken from the first of them that matched
+type SearchResult struct {
+	TargetID     uuid.UUID
+	Name         string
+	Country      string
+	CountryName  string
+	TargetState  string
+	MissionID    uuid.UUID
+	MissionState string
+	Rank         float64
+	MatchedIn    string
+	Snippet      string
+}
+
+func (s *SearchSpec) Validate() error {
+	s.Query = strings.TrimSpace(s.Query)
+	if s.Query == "" {
+		return utils.ErrInvalidSearch
+	}
+
+	switch {
+	case s.Limit == 0:
+		s.Limit = DefaultSearchLimit
+	case s.Limit < 0 || s.Limit > MaxSearchLimit:
+		return utils.ErrInvalidSearch
+	}
+
+	return nil
+}
+
+// SearchTargets ranks targets by their name, notes and journal entries. Names and journal entries are matched
+// with the query read in web search syntax, both stemmed and as typed. Notes are encrypted and go through their
+// blind index instead, which matches whole words only: notes match when they hold every term of the query,
+// negated terms are left out. Targets of trashed missions are hidden like everywhere else
+func (r *Repository) SearchTargets(ctx context.Context, spec SearchSpec) ([]*SearchResult, error) {
+	const op = "target.Repository.SearchTargets"
+
+	terms := queryTerms(spec.Query)
+
+	query := `WITH q AS (
+			SELECT websearch_to_tsquery('english', $1) || websearch_to_tsquery('simple', $1) AS query,
+				$4::text[] AS tokens
+		)
+		SELECT t.id, t.name, t.country, t.state, m.id, m.state,
+			(ts_rank(t.search_vector, q.query)
+				+ CASE WHEN cardinality(q.tokens) > 0 AND t.notes_index @> q.tokens THEN $5::float8 ELSE 0 END
+				+ COALESCE(j.rank, 0))::float8 AS rank,
+			cardinality(q.tokens) > 0 AND t.notes_index @> q.tokens, COALESCE(t.notes, ''), t.notes_key_id,
+			CASE WHEN j.rank IS NOT NULL THEN j.snippet ELSE ts_headline('simple', t.name, q.query, $3) END,
+			j.rank IS NOT NULL
+		FROM targets t
+		JOIN missions m ON m.id = t.mission_id
+		CROSS JOIN q
+		LEFT JOIN LATERAL (
+			SELECT SUM(ts_rank(e.search_vector, q.query)) AS rank,
+				ts_headline('english', string_agg(e.body, ' … ' ORDER BY e.observed_at DESC), q.query, $3) AS snippet
+			FROM target_journal_entries e
+			WHERE e.target_id = t.id AND e.search_vector @@ q.query
+		) j ON true
+		WHERE m.deleted_at IS NULL AND t.id IN (
+			SELECT id FROM targets WHERE search_vector @@ (SELECT query FROM q)
+			UNION
+			SELECT id FROM targets WHERE cardinality($4::text[]) > 0 AND notes_index @> $4::text[]
+			UNION
+			SELECT target_id FROM target_journal_entries WHERE search_vector @@ (SELECT query FROM q)
+		)
+		ORDER BY rank DESC, t.id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, query, spec.Query, spec.Limit, headlineOptions, r.keys.BlindIndex(terms), notesMatchRank)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	matchTerms := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		matchTerms[term] = true
+	}
+
+	results := make([]*SearchResult, 0)
+	for rows.Next() {
+		var (
+			result                     SearchResult
+			notesMatched, entryMatched bool
+			notes                      string
+			notesKeyID                 *string
+		)
+
+		err = rows.Scan(
+			&result.TargetID,
+			&result.Name,
+			&result.Country,
+			&result.TargetState,
+			&result.MissionID,
+			&result.MissionState,
+			&result.Rank,
+			&notesMatched,
+			&notes,
+			&notesKeyID,
+			&result.Snippet,
+			&entryMatched,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		switch {
+		case notesMatched:
+			if notes, err = r.openSealed(notes, notesKeyID, notesBinding(result.TargetID)); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			result.MatchedIn, result.Snippet = "notes", headline(notes, matchTerms)
+		case entryMatched:
+			result.MatchedIn = "journal"
+		default:
+			result.MatchedIn = "name"
+		}
+
+		result.CountryName = country.DisplayName(result.Country)
+		results = append(results, &result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+// queryTerms keeps the words of the query the blind index can look for, the negated ones and the or operator
+// of the web search syntax are dropped
+func queryTerms(query string) []string {
+	words := make([]string, 0)
+	for _, word := range strings.Fields(query) {
+		if strings.HasPrefix(word, "-") || strings.EqualFold(word, "or") {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return encryption.Terms(strings.Join(words, " "))
+}
+
+// headline is ts_headline for the decrypted notes: the words around the first match with matches in <mark>
+func headline(text string, terms map[string]bool) string {
+	words := strings.Fields(text)
+
+	matches := func(word string) bool {
+		for _, term := range encryption.Terms(word) {
+			if terms[term] {
+				return true
+			}
+		}
+		return false
+	}
+
+	first := 0
+	for i, word := range words {
+		if matches(word) {
+			first = i
+			break
+		}
+	}
+
+	start := max(0, first-headlineContext)
+	end := min(len(words), start+headlineWords)
+
+	parts := make([]string, 0, end-start+2)
+	if start > 0 {
+		parts = append(parts, "…")
+	}
+
+	for _, word := range words[start:end] {
+		if matches(word) {
+			word = "<mark>" + word + "</mark>"
+		}
+		parts = append(parts, word)
+	}
+
+	if end < len(words) {
+		parts = append(parts, "…")
+	}
+
+	return strings.Join(parts, " ")
+}
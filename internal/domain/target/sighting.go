@@ -0,0 +1,241 @@
+package target
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/geo"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strings"
+	"time"
+)
+
+const (
+	sightingsTableName       = "target_sightings"
+	sightingIDColumn         = "id"
+	sightingTargetIDColumn   = "target_id"
+	sightingCatIDColumn      = "cat_id"
+	sightingLatColumn        = "latitude"
+	sightingLngColumn        = "longitude"
+	sightingDescColumn       = "description"
+	sightingObservedAtColumn = "observed_at"
+	sightingCreatedAtColumn  = "created_at"
+	sightingCatConstraint    = "target_sightings_cat_id_fkey"
+)
+
+var sightingColumns = []string{
+	sightingIDColumn,
+	sightingTargetIDColumn,
+	sightingCatIDColumn,
+	sightingLatColumn,
+	sightingLngColumn,
+	sightingDescColumn,
+	sightingObservedAtColumn,
+	sightingCreatedAtColumn,
+}
+
+// Sighting is a located observation of a target, CatID is the reporting cat and is cleared if the cat is deleted
+type Sighting struct {
+	ID          uuid.UUID
+	TargetID    uuid.UUID
+	CatID       *uuid.UUID
+	Latitude    float64
+	Longitude   float64
+	Description string `validate:"max=1000"`
+	ObservedAt  time.Time
+	CreatedAt   time.Time
+}
+
+type CreateSightingSvc struct {
+	CatID       *uuid.UUID
+	Latitude    float64
+	Longitude   float64
+	Description string
+	ObservedAt  *time.Time
+}
+
+// NewSighting : the observation time defaults to now
+func NewSighting(targetID uuid.UUID, req CreateSightingSvc, now time.Time) *Sighting {
+	sighting := &Sighting{
+		TargetID:    targetID,
+		CatID:       req.CatID,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+		Description: strings.TrimSpace(req.Description),
+		ObservedAt:  now,
+	}
+
+	if req.ObservedAt != nil {
+		sighting.ObservedAt = *req.ObservedAt
+	}
+
+	return sighting
+}
+
+func (s *Sighting) Validate() error {
+	if err := geo.ValidateCoordinates(s.Latitude, s.Longitude); err != nil {
+		return err
+	}
+
+	if s.CatID == nil {
+		return utils.ErrInvalidSighting
+	}
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(s); err != nil {
+		return utils.ErrInvalidSighting
+	}
+
+	return nil
+}
+
+// CheckCountry rejects sightings outside the country of the target, unknown countries are not checked
+func (s *Sighting) CheckCountry(country string) error {
+	if inside, known := geo.InCountry(country, s.Latitude, s.Longitude); known && !inside {
+		return utils.ErrOutsideCountry
+	}
+
+	return nil
+}
+
+func scanSighting(row pgx.Row, sighting *Sighting) error {
+	return row.Scan(
+		&sighting.ID,
+		&sighting.TargetID,
+		&sighting.CatID,
+		&sighting.Latitude,
+		&sighting.Longitude,
+		&sighting.Description,
+		&sighting.ObservedAt,
+		&sighting.CreatedAt,
+	)
+}
+
+// AddSighting stores the sighting and moves the last known position of the target,
+// unless the target was already seen later than this observation
+func (r *Repository) AddSighting(ctx context.Context, sighting *Sighting) (uuid.UUID, error) {
+	const op = "target.Repository.AddSighting"
+	var id uuid.UUID
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	query, args, err := r.builder.
+		Insert(sightingsTableName).
+		Columns(sightingTargetIDColumn, sightingCatIDColumn, sightingLatColumn, sightingLngColumn, sightingDescColumn, sightingObservedAtColumn).
+		Values(sighting.TargetID, sighting.CatID, sighting.Latitude, sighting.Longitude, sighting.Description, sighting.ObservedAt).
+		Suffix("RETURNING " + sightingIDColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.QueryRow(ctx, query, args...).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == sightingCatConstraint {
+				return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrCatNotFound)
+			}
+
+			return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrTargetNotFound)
+		}
+
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err = r.builder.Update(tableName).
+		Set(lastLatColumn, sighting.Latitude).
+		Set(lastLngColumn, sighting.Longitude).
+		Set(lastSeenAtColumn, sighting.ObservedAt).
+		Where(sq.Eq{idColumn: sighting.TargetID}).
+		Where(sq.Or{sq.Eq{lastSeenAtColumn: nil}, sq.LtOrEq{lastSeenAtColumn: sighting.ObservedAt}}).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// GetSightings lists the sightings of the target, the oldest first. A target of another mission is reported as not found
+func (r *Repository) GetSightings(ctx context.Context, missionID, targetID uuid.UUID) ([]*Sighting, error) {
+	const op = "target.Repository.GetSightings"
+
+	sightings, err := r.querySightings(ctx, op, inMission(sightingTargetIDColumn, missionID, targetID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sightings) == 0 {
+		if err = r.checkMissionTarget(ctx, missionID, targetID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return sightings, nil
+}
+
+// GetSightingsByMissionID lists the sightings of every target of the mission, the oldest first
+func (r *Repository) GetSightingsByMissionID(ctx context.Context, missionID uuid.UUID) ([]*Sighting, error) {
+	const op = "target.Repository.GetSightingsByMissionID"
+
+	return r.querySightings(ctx, op, sq.Expr(
+		sightingTargetIDColumn+" IN (SELECT "+idColumn+" FROM "+tableName+" WHERE "+missionIDColumn+" = ?)", missionID,
+	))
+}
+
+func (r *Repository) querySightings(ctx context.Context, op string, pred sq.Sqlizer) ([]*Sighting, error) {
+	sightings := make([]*Sighting, 0)
+
+	query, args, err := r.builder.
+		Select(sightingColumns...).
+		From(sightingsTableName).
+		Where(pred).
+		OrderBy(sightingObservedAtColumn, sightingIDColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sighting Sighting
+
+		if err = scanSighting(rows, &sighting); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		sightings = append(sightings, &sighting)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sightings, nil
+}
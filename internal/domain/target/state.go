@@ -0,0 +1,93 @@
+package target
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"time"
+)
+
+const (
+	StateStarted           = "started"
+	StateIdentified        = "identified"
+	StateUnderSurveillance = "under_surveillance"
+	StateContacted         = "contacted"
+	StateNeutralized       = "neutralized"
+	StateEscaped           = "escaped"
+	StateCompleted         = "completed"
+	StateAbandoned         = "abandoned"
+)
+
+// OpenStates targets in these states are still pursued, their notes and journal can change
+var OpenStates = []string{StateStarted, StateIdentified, StateUnderSurveillance, StateContacted}
+
+// TerminalStates targets in these states are frozen, a mission whose targets are all terminal is completed
+var TerminalStates = []string{StateCompleted, StateNeutralized, StateEscaped, StateAbandoned}
+
+// SuccessfulStates targets in these states count as a success of the cat that pursued them
+var SuccessfulStates = []string{StateCompleted, StateNeutralized}
+
+// transitions work on a target goes forward, only a contacted target may drop back to surveillance;
+// every open state may end the pursuit and terminal states are final
+var transitions = map[string][]string{
+	StateStarted:           {StateIdentified, StateUnderSurveillance, StateContacted, StateNeutralized, StateEscaped, StateCompleted, StateAbandoned},
+	StateIdentified:        {StateUnderSurveillance, StateContacted, StateNeutralized, StateEscaped, StateCompleted, StateAbandoned},
+	StateUnderSurveillance: {StateContacted, StateNeutralized, StateEscaped, StateCompleted, StateAbandoned},
+	StateContacted:         {StateUnderSurveillance, StateNeutralized, StateEscaped, StateCompleted, StateAbandoned},
+}
+
+func IsTerminal(state string) bool {
+	return contains(TerminalStates, state)
+}
+
+func IsSuccessful(state string) bool {
+	return contains(SuccessfulStates, state)
+}
+
+// CheckEditable targets in a terminal state are read-only
+func (t *Target) CheckEditable() error {
+	return checkStateEditable(t.State)
+}
+
+// Transition moves the target to the given state, reaching a terminal state stamps the completion time
+func (t *Target) Transition(state string, now time.Time) error {
+	if !contains(OpenStates, state) && !IsTerminal(state) {
+		return utils.ErrUnknownTargetState
+	}
+
+	if err := t.CheckEditable(); err != nil {
+		return err
+	}
+
+	if !contains(transitions[t.State], state) {
+		return utils.ErrTargetTransition
+	}
+
+	if IsTerminal(state) && t.CompletedAt == nil {
+		t.CompletedAt = &now
+	}
+
+	t.State = state
+	return nil
+}
+
+func checkStateEditable(state string) error {
+	switch state {
+	case StateCompleted:
+		return utils.ErrTargetCompleted
+	case StateAbandoned:
+		return utils.ErrTargetAbandoned
+	case StateNeutralized, StateEscaped:
+		return utils.ErrTargetClosed
+	default:
+		return nil
+	}
+}
+
+func contains(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,104 @@
+package template
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Template struct {
+	ID           uuid.UUID
+	Name         string `validate:"required"`
+	MissionType  string `validate:"required"`
+	DefaultNotes string
+	Targets      []TargetSkeleton `validate:"required,dive"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TargetSkeleton is stored as a part of the template JSON document, Name identifies it for overrides
+type TargetSkeleton struct {
+	Name    string  `json:"name" validate:"required"`
+	Country string  `json:"country" validate:"required"`
+	Notes   *string `json:"notes,omitempty"`
+}
+
+type CreateTemplateSvc struct {
+	Name         string
+	MissionType  string
+	DefaultNotes string
+	Targets      []TargetSkeleton
+}
+
+type UpdateTemplateParams struct {
+	ID           uuid.UUID
+	Name         *string
+	MissionType  *string
+	DefaultNotes *string
+	Targets      []TargetSkeleton
+}
+
+func NewEntity(name, missionType, defaultNotes string, targets []TargetSkeleton) *Template {
+	return &Template{
+		Name:         name,
+		MissionType:  missionType,
+		DefaultNotes: defaultNotes,
+		Targets:      targets,
+	}
+}
+
+func (t *Template) Validate() error {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	if err := validate.Struct(t); err != nil {
+		return utils.ErrValidatingTemplate
+	}
+
+	if len(t.Targets) == 0 {
+		return utils.ErrNoTargets
+	}
+
+	if len(t.Targets) > mission.MissionSize {
+		return utils.ErrTargetOverflow
+	}
+
+	names := make(map[string]struct{}, len(t.Targets))
+	for _, skeleton := range t.Targets {
+		if _, ok := names[skeleton.Name]; ok {
+			return utils.ErrValidatingTemplate
+		}
+		names[skeleton.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func (t *Template) HasTarget(name string) bool {
+	for _, skeleton := range t.Targets {
+		if skeleton.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *Template) Update(params UpdateTemplateParams) {
+	if params.Name != nil {
+		t.Name = *(params.Name)
+	}
+
+	if params.MissionType != nil {
+		t.MissionType = *(params.MissionType)
+	}
+
+	if params.DefaultNotes != nil {
+		t.DefaultNotes = *(params.DefaultNotes)
+	}
+
+	if params.Targets != nil {
+		t.Targets = params.Targets
+	}
+}
@@ -0,0 +1,222 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Repository struct {
+	db      *pgxpool.Pool
+	builder sq.StatementBuilderType
+}
+
+const (
+	tableName          = "mission_templates"
+	idColumn           = "id"
+	nameColumn         = "name"
+	missionTypeColumn  = "mission_type"
+	defaultNotesColumn = "default_notes"
+	targetsColumn      = "targets"
+	createdAtColumn    = "created_at"
+	updatedAtColumn    = "updated_at"
+)
+
+var selectColumns = []string{
+	idColumn,
+	nameColumn,
+	missionTypeColumn,
+	defaultNotesColumn,
+	targetsColumn,
+	createdAtColumn,
+	updatedAtColumn,
+}
+
+func NewRepository(pool *pgxpool.Pool) *Repository {
+	builder := sq.StatementBuilderType{}
+	builder = builder.PlaceholderFormat(sq.Dollar)
+	return &Repository{db: pool, builder: builder}
+}
+
+func scanTemplate(row pgx.Row, tmpl *Template) error {
+	var rawTargets []byte
+
+	err := row.Scan(
+		&tmpl.ID,
+		&tmpl.Name,
+		&tmpl.MissionType,
+		&tmpl.DefaultNotes,
+		&rawTargets,
+		&tmpl.CreatedAt,
+		&tmpl.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(rawTargets, &tmpl.Targets)
+}
+
+func (r *Repository) AddTemplate(ctx context.Context, tmpl *Template) (uuid.UUID, error) {
+	const op = "template.Repository.AddTemplate"
+	var id uuid.UUID
+
+	rawTargets, err := json.Marshal(tmpl.Targets)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := r.builder.
+		Insert(tableName).
+		Columns(nameColumn, missionTypeColumn, defaultNotesColumn, targetsColumn).
+		Values(tmpl.Name, tmpl.MissionType, tmpl.DefaultNotes, rawTargets).
+		Suffix("RETURNING " + idColumn).
+		ToSql()
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = r.db.QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return uuid.Nil, fmt.Errorf("%s: %w", op, utils.ErrConflictingData)
+			}
+		}
+
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *Repository) GetTemplateByID(ctx context.Context, id uuid.UUID) (*Template, error) {
+	const op = "template.Repository.GetTemplateByID"
+	var tmpl Template
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		Where(sq.Eq{idColumn: id}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = scanTemplate(r.db.QueryRow(ctx, query, args...), &tmpl); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, utils.ErrTemplateNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &tmpl, nil
+}
+
+func (r *Repository) GetTemplates(ctx context.Context) ([]*Template, error) {
+	const op = "template.Repository.GetTemplates"
+	templates := make([]*Template, 0)
+
+	query, args, err := r.builder.
+		Select(selectColumns...).
+		From(tableName).
+		OrderBy(nameColumn).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tmpl Template
+
+		if err = scanTemplate(rows, &tmpl); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		templates = append(templates, &tmpl)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func (r *Repository) UpdateTemplate(ctx context.Context, tmpl *Template) error {
+	const op = "template.Repository.UpdateTemplate"
+
+	rawTargets, err := json.Marshal(tmpl.Targets)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	query, args, err := r.builder.Update(tableName).
+		Set(nameColumn, tmpl.Name).
+		Set(missionTypeColumn, tmpl.MissionType).
+		Set(defaultNotesColumn, tmpl.DefaultNotes).
+		Set(targetsColumn, rawTargets).
+		Where(sq.Eq{idColumn: tmpl.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var res pgconn.CommandTag
+	res, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if ok := errors.As(err, &pgErr); ok {
+			if pgErr.Code == "23505" {
+				return fmt.Errorf("%s: %w", op, utils.ErrConflictingData)
+			}
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTemplateNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	const op = "template.Repository.DeleteTemplate"
+
+	query, args, err := r.builder.Delete(tableName).Where(sq.Eq{idColumn: id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var res pgconn.CommandTag
+	res, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, utils.ErrTemplateNotFound)
+	}
+
+	return nil
+}
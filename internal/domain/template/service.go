@@ -0,0 +1,91 @@
+package template
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+type Repo interface {
+	AddTemplate(ctx context.Context, tmpl *Template) (uuid.UUID, error)
+	GetTemplateByID(ctx context.Context, id uuid.UUID) (*Template, error)
+	GetTemplates(ctx context.Context) ([]*Template, error)
+	UpdateTemplate(ctx context.Context, tmpl *Template) error
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+}
+
+type Service struct {
+	repo Repo
+}
+
+func NewService(repo Repo) *Service {
+	return &Service{repo: repo}
+}
+
+func (s *Service) CreateTemplate(ctx context.Context, req CreateTemplateSvc) (uuid.UUID, error) {
+	const op = "template.Service.CreateTemplate"
+
+	tmpl := NewEntity(req.Name, req.MissionType, req.DefaultNotes, req.Targets)
+	if err := tmpl.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.repo.AddTemplate(ctx, tmpl)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) GetTemplate(ctx context.Context, id uuid.UUID) (*Template, error) {
+	const op = "template.Service.GetTemplate"
+
+	tmpl, err := s.repo.GetTemplateByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tmpl, nil
+}
+
+func (s *Service) ListTemplates(ctx context.Context) ([]*Template, error) {
+	const op = "template.Service.ListTemplates"
+
+	templates, err := s.repo.GetTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return templates, nil
+}
+
+func (s *Service) UpdateTemplate(ctx context.Context, params UpdateTemplateParams) (*Template, error) {
+	const op = "template.Service.UpdateTemplate"
+
+	tmpl, err := s.repo.GetTemplateByID(ctx, params.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tmpl.Update(params)
+	if err = tmpl.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.repo.UpdateTemplate(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tmpl, nil
+}
+
+func (s *Service) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	const op = "template.Service.DeleteTemplate"
+
+	if err := s.repo.DeleteTemplate(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+package encryption
+
+// Config keys are 32 bytes (AES-256) encoded in base64 and named by an ID that is stored next to every value.
+// The key file holds one "<key-id> <base64 key>" pair per line, Keys the same pairs as "<key-id>:<base64 key>"
+// separated by commas; a key present in both must be the same. ActiveKeyID encrypts new values, the other keys
+// are kept to read values written before a rotation. IndexKey (32 bytes in base64) hashes the blind index terms,
+// it is not rotated with the others since every stored index has to be rebuilt when it changes
+type Config struct {
+	KeyFile     string `yaml:"key-file" env:"NOTES_KEY_FILE"`
+	Keys        string `yaml:"-" env:"NOTES_KEYS"`
+	ActiveKeyID string `yaml:"active-key-id" env:"NOTES_ACTIVE_KEY_ID"`
+	IndexKey    string `yaml:"-" env:"NOTES_INDEX_KEY"`
+}
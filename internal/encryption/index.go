@@ -0,0 +1,50 @@
+package encryption
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"unicode"
+)
+
+const (
+	// tokenSize HMAC-SHA256 is cut to 16 bytes, plenty to keep the terms of a single database apart
+	tokenSize = 16
+
+	minTermLength = 2
+)
+
+// Terms splits text into the lowercase words a blind index is built from, every word once and in order.
+// Words are matched exactly, there is no stemming
+func Terms(text string) []string {
+	seen := make(map[string]bool)
+	terms := make([]string, 0)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), isNotTermRune) {
+		if len([]rune(word)) < minTermLength || seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		terms = append(terms, word)
+	}
+
+	return terms
+}
+
+// BlindIndex turns terms into keyed hashes which can be stored and compared without revealing the terms
+func (k *Keyring) BlindIndex(terms []string) []string {
+	tokens := make([]string, 0, len(terms))
+	for _, term := range terms {
+		mac := hmac.New(sha256.New, k.indexKey)
+		mac.Write([]byte(term))
+		tokens = append(tokens, base64.RawStdEncoding.EncodeToString(mac.Sum(nil)[:tokenSize]))
+	}
+
+	return tokens
+}
+
+func isNotTermRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,222 @@
+package encryption
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const (
+	keySize     = 32
+	nonceSize   = 12
+	tagSize     = 16
+	wrappedSize = nonceSize + keySize + tagSize
+
+	// formatVersion leads every sealed value so the layout can change without guessing
+	formatVersion byte = 1
+)
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// Keyring seals values with envelope encryption: every value gets its own data key, the data key is
+// encrypted (wrapped) with a key of the ring and stored with the value. Rotating only changes which key
+// wraps new data keys, values wrapped with older keys stay readable as long as those keys are configured
+type Keyring struct {
+	keys     map[string]cipher.AEAD
+	activeID string
+	indexKey []byte
+}
+
+func NewKeyring(cfg Config) (*Keyring, error) {
+	const op = "encryption.NewKeyring"
+
+	raw := make(map[string][]byte)
+
+	if cfg.KeyFile != "" {
+		content, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read key file: %w", op, err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if text == "" || strings.HasPrefix(text, "#") {
+				continue
+			}
+
+			fields := strings.Fields(text)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("%s: key file line %d: expected \"<key-id> <base64 key>\"", op, line)
+			}
+
+			if err = addKey(raw, fields[0], fields[1]); err != nil {
+				return nil, fmt.Errorf("%s: key file line %d: %w", op, line, err)
+			}
+		}
+	}
+
+	for _, pair := range strings.Split(cfg.Keys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		id, encoded, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("%s: expected \"<key-id>:<base64 key>\" pairs in keys", op)
+		}
+
+		if err := addKey(raw, strings.TrimSpace(id), strings.TrimSpace(encoded)); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("%s: no encryption keys configured", op)
+	}
+
+	if _, ok := raw[cfg.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("%s: active key %q is not configured", op, cfg.ActiveKeyID)
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(cfg.IndexKey))
+	if err != nil || len(indexKey) != keySize {
+		return nil, fmt.Errorf("%s: index key must be %d bytes encoded in base64", op, keySize)
+	}
+
+	ring := &Keyring{keys: make(map[string]cipher.AEAD, len(raw)), activeID: cfg.ActiveKeyID, indexKey: indexKey}
+	for id, key := range raw {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		ring.keys[id] = aead
+	}
+
+	return ring, nil
+}
+
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// Seal encrypts the value with a fresh data key wrapped by the active key, the returned key ID has to be
+// stored with the value to open it again. The value only opens with the same binding, which names the
+// place it is stored at so it can't be copied to another one
+func (k *Keyring) Seal(plaintext string, binding []byte) (string, string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", "", err
+	}
+
+	wrapped, err := seal(k.keys[k.activeID], dataKey, []byte(k.activeID))
+	if err != nil {
+		return "", "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	sealed, err := seal(aead, []byte(plaintext), additionalData(binding))
+	if err != nil {
+		return "", "", err
+	}
+
+	blob := make([]byte, 0, 1+len(wrapped)+len(sealed))
+	blob = append(blob, formatVersion)
+	blob = append(blob, wrapped...)
+	blob = append(blob, sealed...)
+
+	return base64.StdEncoding.EncodeToString(blob), k.activeID, nil
+}
+
+// Open decrypts a value sealed with the key of the given ID and the same binding
+func (k *Keyring) Open(value, keyID string, binding []byte) (string, error) {
+	keyAEAD, ok := k.keys[keyID]
+	if !ok {
+		return "", utils.ErrUnknownNotesKey
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(blob) < 1+wrappedSize+nonceSize+tagSize || blob[0] != formatVersion {
+		return "", utils.ErrNotesDecryption
+	}
+
+	dataKey, err := open(keyAEAD, blob[1:1+wrappedSize], []byte(keyID))
+	if err != nil {
+		return "", utils.ErrNotesDecryption
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(aead, blob[1+wrappedSize:], additionalData(binding))
+	if err != nil {
+		return "", utils.ErrNotesDecryption
+	}
+
+	return string(plaintext), nil
+}
+
+func additionalData(binding []byte) []byte {
+	return append([]byte{formatVersion}, binding...)
+}
+
+func addKey(keys map[string][]byte, id, encoded string) error {
+	if !keyIDPattern.MatchString(id) {
+		return fmt.Errorf("invalid key id %q", id)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != keySize {
+		return fmt.Errorf("key %q must be %d bytes encoded in base64", id, keySize)
+	}
+
+	if existing, ok := keys[id]; ok && !bytes.Equal(existing, key) {
+		return fmt.Errorf("key %q is configured twice with different values", id)
+	}
+
+	keys[id] = key
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal prepends the random nonce to the ciphertext
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+func open(aead cipher.AEAD, sealed, additional []byte) ([]byte, error) {
+	if len(sealed) < nonceSize+tagSize {
+		return nil, errors.New("sealed value is too short")
+	}
+
+	return aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additional)
+}
@@ -0,0 +1,6 @@
+package export
+
+// Config TemplatesDir is optional, its *.md.tmpl files are added to the built-in ones and replace them on a name clash
+type Config struct {
+	TemplatesDir string `yaml:"templates-dir" env:"EXPORT_TEMPLATES_DIR"`
+}
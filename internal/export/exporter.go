@@ -0,0 +1,230 @@
+package export
+
+import (
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	FormatMarkdown = "md"
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+
+	DefaultTemplate = "debrief"
+
+	templateExt = ".md.tmpl"
+)
+
+//go:embed templates/*.md.tmpl
+var builtinTemplates embed.FS
+
+var contentTypes = map[string]string{
+	FormatMarkdown: "text/markdown; charset=utf-8",
+	FormatJSON:     "application/json; charset=utf-8",
+	FormatCSV:      "text/csv; charset=utf-8",
+}
+
+type Exporter struct {
+	templates *template.Template
+}
+
+func New(cfg Config) (*Exporter, error) {
+	const op = "export.New"
+
+	templates, err := template.New("").Funcs(templateFuncs).ParseFS(builtinTemplates, "templates/*"+templateExt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if cfg.TemplatesDir != "" {
+		pattern := filepath.Join(cfg.TemplatesDir, "*"+templateExt)
+
+		// ParseGlob fails on an empty match, an empty directory just means no custom templates
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if len(matches) > 0 {
+			if templates, err = templates.ParseGlob(pattern); err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	return &Exporter{templates: templates}, nil
+}
+
+func (e *Exporter) ContentType(format string) (string, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return "", utils.ErrUnsupportedFormat
+	}
+
+	return contentType, nil
+}
+
+// Export writes the debrief in the given format, templateName selects the Markdown template and is ignored otherwise
+func (e *Exporter) Export(w io.Writer, format, templateName string, debrief *service.Debrief) error {
+	switch format {
+	case FormatMarkdown:
+		return e.exportMarkdown(w, templateName, debrief)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(debrief)
+	case FormatCSV:
+		return exportCSV(w, debrief)
+	default:
+		return utils.ErrUnsupportedFormat
+	}
+}
+
+func (e *Exporter) exportMarkdown(w io.Writer, templateName string, debrief *service.Debrief) error {
+	if templateName == "" {
+		templateName = DefaultTemplate
+	}
+
+	tmpl := e.templates.Lookup(templateName + templateExt)
+	if tmpl == nil {
+		return utils.ErrUnknownExportTmpl
+	}
+
+	return tmpl.Execute(w, debrief)
+}
+
+// exportCSV uses a long section,subject_id,field,value layout so missions, cats, targets and events share one table
+func exportCSV(w io.Writer, debrief *service.Debrief) error {
+	writer := csv.NewWriter(w)
+	mis := debrief.Mission
+	missionID := mis.ID.String()
+
+	rows := [][]string{
+		{"section", "subject_id", "field", "value"},
+		{"mission", missionID, "type", mis.Type},
+		{"mission", missionID, "state", mis.State},
+		{"mission", missionID, "created_at", formatTime(mis.CreatedAt)},
+		{"mission", missionID, "started_at", formatTime(mis.StartedAt)},
+		{"mission", missionID, "deadline", formatTime(mis.Deadline)},
+		{"mission", missionID, "overdue", strconv.FormatBool(mis.Overdue)},
+		{"mission", missionID, "budget", formatMoney(mis.BudgetCents)},
+	}
+
+	if mis.Cost != nil {
+		rows = append(rows, []string{"mission", missionID, "cost", formatMoney(mis.Cost.TotalCents)})
+	}
+
+	if mis.Abort != nil {
+		rows = append(rows,
+			[]string{"mission", missionID, "aborted_at", formatTime(mis.Abort.AbortedAt)},
+			[]string{"mission", missionID, "abort_reason", mis.Abort.Reason},
+			[]string{"mission", missionID, "abort_debrief", mis.Abort.Debrief},
+		)
+	}
+
+	if c := mis.Cat; c != nil {
+		catID := c.ID.String()
+		rows = append(rows,
+			[]string{"cat", catID, "name", c.Name},
+			[]string{"cat", catID, "breed", c.Breed},
+			[]string{"cat", catID, "experience_years", strconv.Itoa(c.YearsXP)},
+			[]string{"cat", catID, "salary", formatMoney(c.SalaryCents)},
+		)
+	}
+
+	for _, tar := range mis.Targets {
+		targetID := tar.ID.String()
+		rows = append(rows,
+			[]string{"target", targetID, "name", tar.Name},
+			[]string{"target", targetID, "country", tar.Country},
+			[]string{"target", targetID, "state", tar.State},
+			[]string{"target", targetID, "completed_at", formatTime(tar.CompletedAt)},
+			[]string{"target", targetID, "notes", tar.Notes},
+		)
+	}
+
+	for _, ev := range debrief.Timeline {
+		eventID := ev.ID.String()
+		rows = append(rows,
+			[]string{"event", eventID, "at", formatTime(ev.CreatedAt)},
+			[]string{"event", eventID, "actor", ev.Actor},
+			[]string{"event", eventID, "type", ev.Type},
+			[]string{"event", eventID, "payload", formatJSON(ev.Payload)},
+		)
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+var templateFuncs = template.FuncMap{
+	"datetime": formatTime,
+	"money":    formatMoney,
+	"json":     formatJSON,
+	"cell":     markdownCell,
+	"inc":      func(i int) int { return i + 1 },
+}
+
+// formatTime accepts both time.Time and *time.Time, missing values are rendered as a dash
+func formatTime(value interface{}) string {
+	switch t := value.(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if t != nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	return "-"
+}
+
+// formatMoney accepts both int64 and *int64 cents
+func formatMoney(value interface{}) string {
+	var cents int64
+	switch c := value.(type) {
+	case int64:
+		cents = c
+	case *int64:
+		if c == nil {
+			return "-"
+		}
+		cents = *c
+	default:
+		return "-"
+	}
+
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func formatJSON(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	return string(raw)
+}
+
+// markdownCell keeps free text from breaking out of a table cell
+func markdownCell(value string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(value)
+}
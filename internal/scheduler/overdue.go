@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"go.uber.org/zap"
+	"time"
+)
+
+type OverdueService interface {
+	MarkOverdueMissions(ctx context.Context) ([]*mission.Mission, error)
+}
+
+// Notifier is informed about every mission which has just missed its deadline
+type Notifier interface {
+	NotifyOverdue(ctx context.Context, mis *mission.Mission) error
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) NotifyOverdue(ctx context.Context, mis *mission.Mission) error {
+	fields := []zap.Field{zap.String("mission_id", mis.ID.String())}
+	if mis.Deadline != nil {
+		fields = append(fields, zap.String("deadline", mis.Deadline.Format(time.RFC3339)))
+	}
+
+	logger.GetLoggerFromCtx(ctx).Info("Mission is overdue", fields...)
+	return nil
+}
+
+func OverdueJob(svc OverdueService, notifier Notifier) Job {
+	return func(ctx context.Context) error {
+		const op = "scheduler.OverdueJob"
+
+		missions, err := svc.MarkOverdueMissions(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, mis := range missions {
+			if err = notifier.NotifyOverdue(ctx, mis); err != nil {
+				logger.GetLoggerFromCtx(ctx).Error(op, err, zap.String("mission_id", mis.ID.String()))
+			}
+		}
+
+		return nil
+	}
+}
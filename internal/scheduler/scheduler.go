@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+type Config struct {
+	OverdueInterval  time.Duration `yaml:"overdue-interval" env:"SCHEDULER_OVERDUE_INTERVAL" env-default:"1m"`
+	ScheduleInterval time.Duration `yaml:"schedule-interval" env:"SCHEDULER_SCHEDULE_INTERVAL" env-default:"30s"`
+	PurgeInterval    time.Duration `yaml:"purge-interval" env:"SCHEDULER_PURGE_INTERVAL" env-default:"1h"`
+}
+
+// Job is a single periodic unit of work, an error is logged and does not stop the schedule
+type Job func(ctx context.Context) error
+
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(ctx context.Context) *Scheduler {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Scheduler{ctx: ctx, cancel: cancel}
+}
+
+// Every runs the job once per interval in its own goroutine until Stop is called
+func (s *Scheduler) Every(name string, interval time.Duration, job Job) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		logger.GetLoggerFromCtx(s.ctx).Info("Scheduled job started", zap.String("job", name), zap.Duration("interval", interval))
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				logger.GetLoggerFromCtx(s.ctx).Info("Scheduled job stopped", zap.String("job", name))
+				return
+			case <-ticker.C:
+				if err := job(s.ctx); err != nil {
+					logger.GetLoggerFromCtx(s.ctx).Error("Scheduled job failed", err, zap.String("job", name))
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels all jobs and waits for the running ones to return
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+
+	logger.GetLoggerFromCtx(s.ctx).Info("Successful graceful shutdown of scheduler")
+}
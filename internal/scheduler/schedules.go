@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ScheduleService interface {
+	RunDueSchedules(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+}
+
+// SchedulesJob materializes due mission schedules, missions are created on behalf of the system actor
+func SchedulesJob(svc ScheduleService) Job {
+	return func(ctx context.Context) error {
+		const op = "scheduler.SchedulesJob"
+
+		created, err := svc.RunDueSchedules(event.WithActor(ctx, event.SystemActor), time.Now())
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, id := range created {
+			logger.GetLoggerFromCtx(ctx).Info("Scheduled mission created", zap.String("mission_id", id.String()))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type TrashService interface {
+	PurgeTrash(ctx context.Context) (int64, error)
+}
+
+func PurgeTrashJob(svc TrashService) Job {
+	return func(ctx context.Context) error {
+		const op = "scheduler.PurgeTrashJob"
+
+		purged, err := svc.PurgeTrash(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if purged > 0 {
+			logger.GetLoggerFromCtx(ctx).Info("Trashed missions purged", zap.Int64("count", purged))
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"strings"
+)
+
+func (s *Service) ApproveMission(ctx context.Context, id uuid.UUID, approver, comment string) (*FullMission, error) {
+	return s.decideApproval(ctx, id, approver, mission.ApprovalApproved, comment)
+}
+
+// RejectMission a single rejection is final, the mission has to be cloned to be submitted again
+func (s *Service) RejectMission(ctx context.Context, id uuid.UUID, approver, comment string) (*FullMission, error) {
+	return s.decideApproval(ctx, id, approver, mission.ApprovalRejected, comment)
+}
+
+// decideApproval the number of required approvals follows the current targets of the mission
+func (s *Service) decideApproval(ctx context.Context, id uuid.UUID, approver, decision, comment string) (*FullMission, error) {
+	const op = "service.decideApproval"
+
+	approver = strings.TrimSpace(approver)
+	if approver == "" {
+		return nil, utils.ErrApproverRequired
+	}
+
+	mis, err := s.mr.GetMissionByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	targets, err := s.tr.GetTargetsByMissionID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	status, err := s.mr.DecideApproval(ctx, &mission.Approval{
+		MissionID: id,
+		Approver:  approver,
+		Decision:  decision,
+		Comment:   comment,
+	}, s.requiredApprovals(targetCountries(targets)))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, id, event.ApprovalDecided, map[string]interface{}{
+		"approver":        approver,
+		"decision":        decision,
+		"comment":         comment,
+		"approval_status": status,
+	})
+
+	return s.GetMission(ctx, id)
+}
+
+// recheckApproval follows the approval status to the current target countries of the mission,
+// so a mission can not stay approved after gaining a target which needs more approvals
+func (s *Service) recheckApproval(ctx context.Context, missionID uuid.UUID) error {
+	const op = "service.recheckApproval"
+
+	targets, err := s.tr.GetTargetsByMissionID(ctx, missionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	required := s.requiredApprovals(targetCountries(targets))
+
+	status, changed, err := s.mr.RecheckApproval(ctx, missionID, required)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if changed {
+		s.record(ctx, missionID, event.ApprovalRechecked, map[string]interface{}{
+			"required":        required,
+			"approval_status": status,
+		})
+	}
+
+	return nil
+}
+
+// requiredApprovals the strictest rule matching any of the countries wins
+func (s *Service) requiredApprovals(countries []string) int {
+	required := s.cfg.Approval.DefaultApprovals
+	for _, rule := range s.cfg.Approval.Rules {
+		if rule.Approvals > required && matchesAnyCountry(rule.Countries, countries) {
+			required = rule.Approvals
+		}
+	}
+
+	return required
+}
+
+// matchesAnyCountry countries are compared by their ISO code, so rules may name a country in any accepted form
+func matchesAnyCountry(ruleCountries, countries []string) bool {
+	for _, ruleCountry := range ruleCountries {
+		for _, targetCountry := range countries {
+			if strings.EqualFold(country.Code(ruleCountry), country.Code(targetCountry)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func targetCountries(targets []*target.Target) []string {
+	countries := make([]string, 0, len(targets))
+	for _, tar := range targets {
+		countries = append(countries, tar.Country)
+	}
+
+	return countries
+}
+
+func (s *Service) newApprovalInfo(mis *mission.Mission, targets []*target.Target) *ApprovalInfo {
+	return &ApprovalInfo{Status: mis.ApprovalStatus, Required: s.requiredApprovals(targetCountries(targets))}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ScoreBreakdown every component is normalized against the best value among the candidates
+type ScoreBreakdown struct {
+	Experience float64
+	Success    float64
+	Load       float64
+	Cost       float64
+	Total      float64
+}
+
+type RankedCat struct {
+	Cat              *cat.Cat
+	CompletedTargets int
+	TotalTargets     int
+	RecentMissions   int
+	Score            ScoreBreakdown
+}
+
+type AutoAssignResult struct {
+	MissionID  uuid.UUID
+	DryRun     bool
+	Assigned   *RankedCat
+	Candidates []*RankedCat
+}
+
+// AutoAssignCat ranks the available cats for the mission and assigns the best one unless dryRun is set
+func (s *Service) AutoAssignCat(ctx context.Context, missionID uuid.UUID, dryRun bool) (*AutoAssignResult, error) {
+	const op = "service.AutoAssignCat"
+
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	if mis.CatID != nil && !dryRun {
+		return nil, utils.ErrCatAssigned
+	}
+
+	if !dryRun {
+		if mis.ApprovalStatus != mission.ApprovalApproved {
+			return nil, utils.ErrMissionNotApproved
+		}
+
+		if err = s.checkPrerequisites(ctx, missionID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	targets, err := s.tr.GetTargetsByMissionID(ctx, missionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	countries := make([]string, 0, len(targets))
+	for _, tar := range targets {
+		countries = append(countries, tar.Country)
+	}
+
+	candidates, err := s.mr.GetAssignmentCandidates(ctx, countries, time.Now().Add(-s.cfg.AutoAssign.LoadWindow))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ranked := rankCandidates(candidates, s.cfg.AutoAssign)
+	result := &AutoAssignResult{MissionID: missionID, DryRun: dryRun, Candidates: ranked}
+
+	if dryRun {
+		return result, nil
+	}
+
+	if len(ranked) == 0 {
+		return nil, utils.ErrNoAvailableCats
+	}
+
+	if err = s.assignCat(ctx, missionID, ranked[0].Cat.ID, true); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	result.Assigned = ranked[0]
+	return result, nil
+}
+
+func rankCandidates(candidates []*mission.Candidate, cfg AutoAssignConfig) []*RankedCat {
+	var maxXP, maxRecent int
+	var maxSalary int64
+	for _, candidate := range candidates {
+		maxXP = max(maxXP, candidate.Cat.YearsXP)
+		maxRecent = max(maxRecent, candidate.RecentMissions)
+		maxSalary = max(maxSalary, candidate.Cat.SalaryCents)
+	}
+
+	ranked := make([]*RankedCat, 0, len(candidates))
+	for _, candidate := range candidates {
+		var score ScoreBreakdown
+
+		if maxXP > 0 {
+			score.Experience = float64(candidate.Cat.YearsXP) / float64(maxXP)
+		}
+
+		if candidate.TotalTargets > 0 {
+			score.Success = float64(candidate.CompletedTargets) / float64(candidate.TotalTargets)
+		}
+
+		// the less busy and the cheaper the cat is, the higher it is ranked
+		score.Load = 1
+		if maxRecent > 0 {
+			score.Load = 1 - float64(candidate.RecentMissions)/float64(maxRecent)
+		}
+
+		score.Cost = 1
+		if maxSalary > 0 {
+			score.Cost = 1 - float64(candidate.Cat.SalaryCents)/float64(maxSalary)
+		}
+
+		score.Total = cfg.ExperienceWeight*score.Experience +
+			cfg.SuccessWeight*score.Success +
+			cfg.LoadWeight*score.Load +
+			cfg.CostWeight*score.Cost
+
+		ranked = append(ranked, &RankedCat{
+			Cat:              candidate.Cat,
+			CompletedTargets: candidate.CompletedTargets,
+			TotalTargets:     candidate.TotalTargets,
+			RecentMissions:   candidate.RecentMissions,
+			Score:            score,
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score.Total != ranked[j].Score.Total {
+			return ranked[i].Score.Total > ranked[j].Score.Total
+		}
+		return ranked[i].Cat.ID.String() < ranked[j].Cat.ID.String()
+	})
+
+	return ranked
+}
@@ -0,0 +1,58 @@
+package service
+
+import "time"
+
+type Config struct {
+	AutoAssign AutoAssignConfig `yaml:"auto-assign"`
+	Cost       CostConfig       `yaml:"cost"`
+	Expenses   ExpensesConfig   `yaml:"expenses"`
+	Approval   ApprovalConfig   `yaml:"approval"`
+	Trash      TrashConfig      `yaml:"trash"`
+	Schedules  SchedulesConfig  `yaml:"schedules"`
+}
+
+// AutoAssignConfig weights are applied to normalized [0..1] scores, so only their proportions matter
+type AutoAssignConfig struct {
+	ExperienceWeight float64       `yaml:"experience-weight" env:"AUTO_ASSIGN_EXPERIENCE_WEIGHT" env-default:"0.4"`
+	SuccessWeight    float64       `yaml:"success-weight" env:"AUTO_ASSIGN_SUCCESS_WEIGHT" env-default:"0.3"`
+	LoadWeight       float64       `yaml:"load-weight" env:"AUTO_ASSIGN_LOAD_WEIGHT" env-default:"0.2"`
+	CostWeight       float64       `yaml:"cost-weight" env:"AUTO_ASSIGN_COST_WEIGHT" env-default:"0.1"`
+	LoadWindow       time.Duration `yaml:"load-window" env:"AUTO_ASSIGN_LOAD_WINDOW" env-default:"720h"`
+}
+
+// CostConfig SalaryPeriod is the span a cat salary is paid for
+type CostConfig struct {
+	SalaryPeriod time.Duration `yaml:"salary-period" env:"COST_SALARY_PERIOD" env-default:"720h"`
+}
+
+// ExpensesConfig claims in other currencies than BaseCurrency are reported separately and never converted
+type ExpensesConfig struct {
+	BaseCurrency    string        `yaml:"base-currency" env:"EXPENSES_BASE_CURRENCY" env-default:"USD"`
+	GracePeriod     time.Duration `yaml:"grace-period" env:"EXPENSES_GRACE_PERIOD" env-default:"168h"`
+	ReceiptDir      string        `yaml:"receipt-dir" env:"EXPENSES_RECEIPT_DIR" env-default:"./data/receipts"`
+	MaxReceiptBytes int64         `yaml:"max-receipt-bytes" env:"EXPENSES_MAX_RECEIPT_BYTES" env-default:"5242880"`
+}
+
+// TrashConfig deleted missions can be restored until Retention runs out, then they are purged for good
+type TrashConfig struct {
+	Retention time.Duration `yaml:"retention" env:"TRASH_RETENTION" env-default:"720h"`
+}
+
+// ApprovalConfig a mission needs the highest number of approvals among the rules matching any of its target
+// countries, DefaultApprovals when none match. Zero approvals approves the mission on creation
+type ApprovalConfig struct {
+	DefaultApprovals int            `yaml:"default-approvals" env:"APPROVAL_DEFAULT_APPROVALS" env-default:"1"`
+	Rules            []ApprovalRule `yaml:"rules"`
+}
+
+type ApprovalRule struct {
+	Countries []string `yaml:"countries"`
+	Approvals int      `yaml:"approvals"`
+}
+
+// SchedulesConfig a claimed occurrence still without a mission after ClaimTimeout was left by a crashed run
+// and is taken over, after MaxAttempts the occurrence is given up and the schedule moves on
+type SchedulesConfig struct {
+	ClaimTimeout time.Duration `yaml:"claim-timeout" env:"SCHEDULES_CLAIM_TIMEOUT" env-default:"5m"`
+	MaxAttempts  int           `yaml:"max-attempts" env:"SCHEDULES_MAX_ATTEMPTS" env-default:"5"`
+}
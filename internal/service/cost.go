@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/expense"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+const reportMonthLayout = "2006-01"
+
+// MissionCost salary is pro-rated over the active period of the mission for the currently assigned cat,
+// only approved and reimbursed claims in the base currency are part of the totals
+type MissionCost struct {
+	MissionID       uuid.UUID
+	BudgetCents     *int64
+	SalaryCents     int64
+	ExpensesCents   int64
+	ForeignExpenses map[string]int64
+	TotalCents      int64
+	OverBudget      bool
+	ActiveFrom      time.Time
+	ActiveTo        time.Time
+}
+
+type MonthlyCost struct {
+	Month           string
+	SalaryCents     int64
+	ExpensesCents   int64
+	ForeignExpenses map[string]int64
+	TotalCents      int64
+}
+
+// CatPayroll PendingCents are approved claims not reimbursed yet, they are included in ExpensesCents
+type CatPayroll struct {
+	CatID           uuid.UUID
+	SalaryCents     int64
+	ExpensesCents   int64
+	PendingCents    int64
+	ForeignExpenses map[string]int64
+	TotalCents      int64
+}
+
+func (s *Service) GetMissionCost(ctx context.Context, id uuid.UUID) (*MissionCost, error) {
+	const op = "service.GetMissionCost"
+
+	fullMis, err := s.GetMission(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return fullMis.Cost, nil
+}
+
+// CostReport aggregates salaries and expenses of all missions by calendar month (UTC) within [from, to)
+func (s *Service) CostReport(ctx context.Context, from, to time.Time) ([]*MonthlyCost, error) {
+	const op = "service.CostReport"
+
+	from, to = from.UTC(), to.UTC()
+	if !to.After(from) {
+		return nil, utils.ErrInvalidPeriod
+	}
+
+	bases, err := s.mr.GetAssignedMissionsActiveBetween(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expenses, err := s.er.GetExpensesBetween(ctx, from, to, expense.CountedStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	months := make([]*MonthlyCost, 0)
+	byMonth := make(map[string]*MonthlyCost)
+
+	for monthStart := monthOf(from); monthStart.Before(to); monthStart = monthStart.AddDate(0, 1, 0) {
+		monthEnd := monthStart.AddDate(0, 1, 0)
+		month := &MonthlyCost{
+			Month:           monthStart.Format(reportMonthLayout),
+			ForeignExpenses: make(map[string]int64),
+		}
+
+		for _, basis := range bases {
+			activeFrom, activeTo := basis.Mission.ActivePeriod(now)
+			month.SalaryCents += s.accruedSalary(
+				basis.SalaryCents,
+				latest(activeFrom, monthStart, from),
+				earliest(activeTo, monthEnd, to),
+			)
+		}
+
+		months = append(months, month)
+		byMonth[month.Month] = month
+	}
+
+	for _, exp := range expenses {
+		month, ok := byMonth[exp.CreatedAt.UTC().Format(reportMonthLayout)]
+		if !ok {
+			continue
+		}
+
+		if exp.Currency == s.cfg.Expenses.BaseCurrency {
+			month.ExpensesCents += exp.AmountCents
+		} else {
+			month.ForeignExpenses[exp.Currency] += exp.AmountCents
+		}
+	}
+
+	for _, month := range months {
+		month.TotalCents = month.SalaryCents + month.ExpensesCents
+	}
+
+	return months, nil
+}
+
+// PayrollReport sums up per cat the salary accrued and the claims submitted within [from, to)
+func (s *Service) PayrollReport(ctx context.Context, from, to time.Time) ([]*CatPayroll, error) {
+	const op = "service.PayrollReport"
+
+	from, to = from.UTC(), to.UTC()
+	if !to.After(from) {
+		return nil, utils.ErrInvalidPeriod
+	}
+
+	bases, err := s.mr.GetAssignedMissionsActiveBetween(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expenses, err := s.er.GetExpensesBetween(ctx, from, to, expense.CountedStatuses)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	now := time.Now()
+	payrolls := make([]*CatPayroll, 0)
+	byCat := make(map[uuid.UUID]*CatPayroll)
+
+	payrollOf := func(catID uuid.UUID) *CatPayroll {
+		payroll, ok := byCat[catID]
+		if !ok {
+			payroll = &CatPayroll{CatID: catID, ForeignExpenses: make(map[string]int64)}
+			byCat[catID] = payroll
+			payrolls = append(payrolls, payroll)
+		}
+
+		return payroll
+	}
+
+	for _, basis := range bases {
+		activeFrom, activeTo := basis.Mission.ActivePeriod(now)
+		payrollOf(*basis.Mission.CatID).SalaryCents += s.accruedSalary(
+			basis.SalaryCents,
+			latest(activeFrom, from),
+			earliest(activeTo, to),
+		)
+	}
+
+	for _, exp := range expenses {
+		// expenses logged before claims existed have no claimant
+		if exp.CatID == nil {
+			continue
+		}
+
+		payroll := payrollOf(*exp.CatID)
+		if exp.Currency != s.cfg.Expenses.BaseCurrency {
+			payroll.ForeignExpenses[exp.Currency] += exp.AmountCents
+			continue
+		}
+
+		payroll.ExpensesCents += exp.AmountCents
+		if exp.Status == expense.StatusApproved {
+			payroll.PendingCents += exp.AmountCents
+		}
+	}
+
+	for _, payroll := range payrolls {
+		payroll.TotalCents = payroll.SalaryCents + payroll.ExpensesCents
+	}
+
+	return payrolls, nil
+}
+
+// missionCost does not query anything, the cat and the expenses totals are expected to be loaded by the caller
+func (s *Service) missionCost(fullMis *FullMission, mis *mission.Mission, expensesByCurrency map[string]int64) *MissionCost {
+	activeFrom, activeTo := mis.ActivePeriod(time.Now())
+
+	cost := &MissionCost{
+		MissionID:       mis.ID,
+		BudgetCents:     mis.BudgetCents,
+		ForeignExpenses: make(map[string]int64),
+		ActiveFrom:      activeFrom,
+		ActiveTo:        activeTo,
+	}
+
+	for currency, cents := range expensesByCurrency {
+		if currency == s.cfg.Expenses.BaseCurrency {
+			cost.ExpensesCents += cents
+		} else {
+			cost.ForeignExpenses[currency] = cents
+		}
+	}
+
+	if fullMis.Cat != nil {
+		cost.SalaryCents = s.accruedSalary(fullMis.Cat.SalaryCents, activeFrom, activeTo)
+	}
+
+	cost.TotalCents = cost.SalaryCents + cost.ExpensesCents
+	cost.OverBudget = mis.BudgetCents != nil && cost.TotalCents > *mis.BudgetCents
+
+	return cost
+}
+
+// accruedSalary salary is paid per configured salary period and accrues linearly
+func (s *Service) accruedSalary(salaryCents int64, from, to time.Time) int64 {
+	if !to.After(from) || s.cfg.Cost.SalaryPeriod <= 0 {
+		return 0
+	}
+
+	return int64(float64(salaryCents) * float64(to.Sub(from)) / float64(s.cfg.Cost.SalaryPeriod))
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func latest(times ...time.Time) time.Time {
+	result := times[0]
+	for _, t := range times[1:] {
+		if t.After(result) {
+			result = t
+		}
+	}
+
+	return result
+}
+
+func earliest(times ...time.Time) time.Time {
+	result := times[0]
+	for _, t := range times[1:] {
+		if t.Before(result) {
+			result = t
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Debrief is everything known about a mission, Timeline is empty for missions without recorded events
+type Debrief struct {
+	Mission     *FullMission
+	Timeline    []*event.Event
+	GeneratedAt time.Time
+}
+
+func (s *Service) GetMissionDebrief(ctx context.Context, id uuid.UUID) (*Debrief, error) {
+	const op = "service.GetMissionDebrief"
+
+	fullMis, err := s.GetMission(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	debrief := &Debrief{Mission: fullMis, Timeline: make([]*event.Event, 0), GeneratedAt: time.Now().UTC()}
+
+	var cursor *mission.Cursor
+	for {
+		page, err := s.evr.GetMissionEvents(ctx, id, cursor, mission.MaxPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		debrief.Timeline = append(debrief.Timeline, page.Events...)
+		if page.NextCursor == "" {
+			break
+		}
+
+		if cursor, err = mission.DecodeCursor(page.NextCursor); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return debrief, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// AddMissionDependency makes the mission wait until the prerequisite is completed
+func (s *Service) AddMissionDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error {
+	const op = "service.AddMissionDependency"
+
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return err
+	}
+
+	// a trashed prerequisite would block the mission without being visible anywhere
+	prerequisite, err := s.mr.GetMissionByID(ctx, prerequisiteID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if prerequisiteFailed(prerequisite) {
+		return fmt.Errorf("%s: %w", op, utils.ErrPrerequisiteFailed)
+	}
+
+	if err = s.mr.AddDependency(ctx, missionID, prerequisiteID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.DependencyAdded, map[string]interface{}{"prerequisite_id": prerequisiteID})
+
+	return nil
+}
+
+func (s *Service) RemoveMissionDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error {
+	const op = "service.RemoveMissionDependency"
+
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return err
+	}
+
+	if err = s.mr.RemoveDependency(ctx, missionID, prerequisiteID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.DependencyRemoved, map[string]interface{}{"prerequisite_id": prerequisiteID})
+
+	return nil
+}
+
+// checkPrerequisites a mission can't be assigned or completed while any of its prerequisites is open.
+// An aborted or trashed prerequisite won't be completed, so it is reported apart
+func (s *Service) checkPrerequisites(ctx context.Context, missionID uuid.UUID) error {
+	open, err := s.mr.GetOpenPrerequisites(ctx, missionID)
+	if err != nil {
+		return err
+	}
+
+	for _, prerequisite := range open {
+		if prerequisiteFailed(prerequisite) {
+			return utils.ErrPrerequisiteFailed
+		}
+	}
+
+	if len(open) > 0 {
+		return utils.ErrMissionBlocked
+	}
+
+	return nil
+}
+
+// failDependents records on the missions waiting for the prerequisite that it was aborted or trashed,
+// they stay blocked until the dependency is removed
+func (s *Service) failDependents(ctx context.Context, prerequisiteID uuid.UUID, reason string) {
+	const op = "service.failDependents"
+
+	dependents, err := s.mr.GetDependents(ctx, prerequisiteID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err)
+		return
+	}
+
+	for _, dependent := range dependents {
+		s.record(ctx, dependent.ID, event.DependencyFailed, map[string]interface{}{
+			"prerequisite_id": prerequisiteID,
+			"reason":          reason,
+		})
+	}
+}
+
+func prerequisiteFailed(prerequisite *mission.Mission) bool {
+	return prerequisite.State == abortedState || prerequisite.DeletedAt != nil
+}
+
+func newDependencyRefs(missions []*mission.Mission) []*DependencyRef {
+	refs := make([]*DependencyRef, 0, len(missions))
+	for _, mis := range missions {
+		refs = append(refs, &DependencyRef{MissionID: mis.ID, Type: mis.Type, State: mis.State, Failed: prerequisiteFailed(mis)})
+	}
+
+	return refs
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/dossier"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"strings"
+)
+
+const dossierSuggestionLimit = 5
+
+// dossierHistoryEvents mission events that belong to the history of every target of the mission
+var dossierHistoryEvents = map[string]bool{
+	event.MissionCreated:   true,
+	event.CatAssigned:      true,
+	event.MissionCompleted: true,
+	event.MissionAborted:   true,
+}
+
+func (s *Service) CreateDossier(ctx context.Context, req dossier.CreateDossierSvc) (uuid.UUID, error) {
+	const op = "service.CreateDossier"
+
+	d := dossier.NewEntity(req)
+	if err := d.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.dr.AddDossier(ctx, d)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) ListDossiers(ctx context.Context) ([]*dossier.Dossier, error) {
+	const op = "service.ListDossiers"
+
+	dossiers, err := s.dr.GetDossiers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dossiers, nil
+}
+
+// GetDossier assembles the dossier with every linked target, its notes and the history of the missions involved.
+// Targets of trashed missions are left out until the mission is restored
+func (s *Service) GetDossier(ctx context.Context, id uuid.UUID) (*DossierView, error) {
+	const op = "service.GetDossier"
+
+	d, err := s.dr.GetDossierByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	targets, err := s.tr.GetTargetsByDossierID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	view := newDossierView(d)
+	missions := make(map[uuid.UUID]*mission.Mission)
+	missionIDs := make([]uuid.UUID, 0)
+	linked := make(map[string]bool, len(targets))
+
+	for _, tar := range targets {
+		mis, ok := missions[tar.MissionID]
+		if !ok {
+			mis, err = s.mr.GetMissionByID(ctx, tar.MissionID)
+			if errors.Is(err, utils.ErrMissionNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			missions[tar.MissionID] = mis
+			missionIDs = append(missionIDs, mis.ID)
+		}
+
+		linked[tar.ID.String()] = true
+		view.Entries = append(view.Entries, newDossierEntry(mis, tar))
+	}
+
+	view.Aliases = dossierAliases(d, view.Entries)
+	view.Status = dossierStatus(view.Entries)
+
+	events, err := s.evr.GetEventsByMissionIDs(ctx, missionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, ev := range events {
+		if dossierHistoryEvents[ev.Type] || linked[fmt.Sprint(ev.Payload["target_id"])] {
+			view.History = append(view.History, ev)
+		}
+	}
+
+	return view, nil
+}
+
+// LinkDossierTarget attaches a target of any mission to the dossier, a link to another dossier is replaced
+func (s *Service) LinkDossierTarget(ctx context.Context, dossierID, targetID uuid.UUID) error {
+	const op = "service.LinkDossierTarget"
+
+	if _, err := s.dr.GetDossierByID(ctx, dossierID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	tar, err := s.tr.GetTargetByID(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = s.mr.GetMissionByID(ctx, tar.MissionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.tr.LinkDossier(ctx, targetID, dossierID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, tar.MissionID, event.DossierLinked, map[string]interface{}{"target_id": targetID, "dossier_id": dossierID})
+
+	return nil
+}
+
+func (s *Service) UnlinkDossierTarget(ctx context.Context, dossierID, targetID uuid.UUID) error {
+	const op = "service.UnlinkDossierTarget"
+
+	tar, err := s.tr.GetTargetByID(ctx, targetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.tr.UnlinkDossier(ctx, targetID, dossierID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, tar.MissionID, event.DossierUnlinked, map[string]interface{}{"target_id": targetID, "dossier_id": dossierID})
+
+	return nil
+}
+
+// SuggestDossiers lists the dossiers the target most likely belongs to, by name and country
+func (s *Service) SuggestDossiers(ctx context.Context, missionID, targetID uuid.UUID) ([]*dossier.Suggestion, error) {
+	const op = "service.SuggestDossiers"
+
+	_, tar, err := s.missionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	candidates, err := s.dr.GetMatchCandidates(ctx, tar.Name, tar.Country)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return dossier.Suggest(candidates, tar.Name, tar.Country, dossierSuggestionLimit), nil
+}
+
+func newDossierView(d *dossier.Dossier) *DossierView {
+	return &DossierView{
+		ID:          d.ID,
+		Name:        d.Name,
+		Country:     d.Country,
+		CountryName: d.CountryName,
+		Description: d.Description,
+		Entries:     make([]*DossierEntry, 0),
+		History:     make([]*event.Event, 0),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+func newDossierEntry(mis *mission.Mission, tar *target.Target) *DossierEntry {
+	return &DossierEntry{
+		MissionID:    mis.ID,
+		MissionType:  mis.Type,
+		MissionState: mis.State,
+		TargetID:     tar.ID,
+		TargetName:   tar.Name,
+		TargetState:  tar.State,
+		Country:      tar.Country,
+		Notes:        tar.Notes,
+		LastPosition: tar.LastPosition,
+		CompletedAt:  tar.CompletedAt,
+	}
+}
+
+// dossierAliases the names the subject was targeted under join the aliases recorded on the dossier
+func dossierAliases(d *dossier.Dossier, entries []*DossierEntry) []string {
+	seen := map[string]bool{strings.ToLower(d.Name): true}
+	aliases := make([]string, 0, len(d.Aliases)+len(entries))
+
+	for _, alias := range d.Aliases {
+		seen[strings.ToLower(alias)] = true
+		aliases = append(aliases, alias)
+	}
+
+	for _, entry := range entries {
+		if !seen[strings.ToLower(entry.TargetName)] {
+			seen[strings.ToLower(entry.TargetName)] = true
+			aliases = append(aliases, entry.TargetName)
+		}
+	}
+
+	return aliases
+}
+
+// dossierStatus a completed or neutralized target closes the dossier, otherwise any target still pursued keeps it active
+func dossierStatus(entries []*DossierEntry) string {
+	if len(entries) == 0 {
+		return dossier.StatusUntracked
+	}
+
+	status := dossier.StatusDormant
+	for _, entry := range entries {
+		switch {
+		case target.IsSuccessful(entry.TargetState):
+			return dossier.StatusClosed
+		case !target.IsTerminal(entry.TargetState) && entry.MissionState == startedState:
+			status = dossier.StatusActive
+		}
+	}
+
+	return status
+}
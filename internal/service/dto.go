@@ -2,19 +2,133 @@ package service
 
 import (
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
 	"github.com/google/uuid"
+	"io"
 	"time"
 )
 
 // FullMission aggregated structure
 type FullMission struct {
-	ID        uuid.UUID
-	Cat       *cat.Cat
-	Targets   []*target.Target
+	ID          uuid.UUID
+	Cat         *cat.Cat
+	Targets     []*target.Target
+	Type        string
+	State       string
+	Deadline    *time.Time
+	StartedAt   *time.Time
+	Overdue     bool
+	ClonedFrom  *uuid.UUID
+	BudgetCents *int64
+	Cost        *MissionCost
+	Abort       *AbortInfo
+	Approval    *ApprovalInfo
+	BlockedBy   []*DependencyRef
+	Blocks      []*DependencyRef
+	DeletedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DependencyRef is a short reference to a related mission, BlockedBy and Blocks are loaded only for a single mission.
+// Failed marks a prerequisite that was aborted or moved to the trash, it blocks until the dependency is removed
+type DependencyRef struct {
+	MissionID uuid.UUID
+	Type      string
 	State     string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	Failed    bool
+}
+
+// AbortInfo is set only for aborted missions
+type AbortInfo struct {
+	Reason    string
+	Debrief   string
+	AbortedAt time.Time
+}
+
+// ApprovalInfo Decisions are loaded only for a single mission
+type ApprovalInfo struct {
+	Status    string
+	Required  int
+	Decisions []*mission.Approval
+}
+
+// DossierView Aliases also hold the names the subject was targeted under, History is oldest first
+type DossierView struct {
+	ID          uuid.UUID
+	Name        string
+	Country     string
+	CountryName string
+	Aliases     []string
+	Description string
+	Status      string
+	Entries     []*DossierEntry
+	History     []*event.Event
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DossierEntry is one linked target together with the state of its mission
+type DossierEntry struct {
+	MissionID    uuid.UUID
+	MissionType  string
+	MissionState string
+	TargetID     uuid.UUID
+	TargetName   string
+	TargetState  string
+	Country      string
+	Notes        string
+	LastPosition *target.Position
+	CompletedAt  *time.Time
+}
+
+// MissionPage NextCursor is empty on the last page
+type MissionPage struct {
+	Missions   []*FullMission
+	NextCursor string
+}
+
+// CreateMissionSvc Type, Deadline, StartedAt and BudgetCents fields are optional
+// CreateMissionSvc scheduleID and occurrenceAt are set only for missions materialized from a schedule
+type CreateMissionSvc struct {
+	Targets     []CreateUpdateTargetSvc
+	Type        string
+	Deadline    *time.Time
+	StartedAt   *time.Time
+	BudgetCents *int64
+
+	scheduleID   *uuid.UUID
+	occurrenceAt *time.Time
+}
+
+// CreateExpenseSvc CatID defaults to the assigned cat, Currency to the base one, Receipt is optional
+type CreateExpenseSvc struct {
+	CatID       *uuid.UUID
+	AmountCents int64
+	Currency    string
+	Category    string
+	Description string
+	Receipt     *ReceiptSvc
+}
+
+type ReceiptSvc struct {
+	Filename string
+	Content  io.Reader
+}
+
+// CreateFromTemplateSvc Overrides are keyed by the name of the template target skeleton
+type CreateFromTemplateSvc struct {
+	Overrides map[string]TargetOverrideSvc
+	Deadline  *time.Time
+	StartedAt *time.Time
+}
+
+type TargetOverrideSvc struct {
+	Name    *string
+	Country *string
+	Notes   *string
 }
 
 // CreateUpdateTargetSvc Notes field is optional
@@ -25,13 +139,10 @@ type CreateUpdateTargetSvc struct {
 }
 
 func MapTargetSvcToEntity(tarReq CreateUpdateTargetSvc) *target.Target {
-	var tar target.Target
-	tar.Name = tarReq.Name
-	tar.Country = tarReq.Country
-
+	notes := ""
 	if tarReq.Notes != nil {
-		tar.Notes = *(tarReq.Notes)
+		notes = *(tarReq.Notes)
 	}
 
-	return &tar
+	return target.NewEntity(tarReq.Name, tarReq.Country, notes)
 }
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/expense"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func (s *Service) AddMissionExpense(ctx context.Context, missionID uuid.UUID, req CreateExpenseSvc) (uuid.UUID, error) {
+	const op = "service.AddMissionExpense"
+
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.checkClaimable(mis, req.CatID, time.Now()); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = s.cfg.Expenses.BaseCurrency
+	}
+
+	exp := expense.NewEntity(missionID, mis.CatID, req.AmountCents, currency, req.Category, req.Description)
+	if err = exp.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.Receipt != nil {
+		receiptPath, err := s.storeReceipt(missionID, req.Receipt)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+		}
+		exp.ReceiptPath = &receiptPath
+	}
+
+	id, err := s.er.AddExpense(ctx, exp)
+	if err != nil {
+		if exp.ReceiptPath != nil {
+			_ = os.Remove(filepath.Join(s.cfg.Expenses.ReceiptDir, *exp.ReceiptPath))
+		}
+
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.ExpenseSubmitted, map[string]interface{}{
+		"expense_id":   id,
+		"amount_cents": exp.AmountCents,
+		"currency":     exp.Currency,
+		"category":     exp.Category,
+	})
+
+	return id, nil
+}
+
+func (s *Service) ListMissionExpenses(ctx context.Context, missionID uuid.UUID) ([]*expense.Expense, error) {
+	const op = "service.ListMissionExpenses"
+
+	if _, err := s.mr.GetMissionByID(ctx, missionID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	expenses, err := s.er.GetExpensesByMissionID(ctx, missionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expenses, nil
+}
+
+// UpdateExpenseStatus reviews or reimburses a claim, note is kept only for reviews
+func (s *Service) UpdateExpenseStatus(ctx context.Context, missionID, expenseID uuid.UUID, status, note string) (*expense.Expense, error) {
+	const op = "service.UpdateExpenseStatus"
+
+	exp, err := s.er.GetExpenseByID(ctx, missionID, expenseID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fromStatus := exp.Status
+	if err = exp.Transition(status, note, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.er.UpdateExpenseStatus(ctx, exp, fromStatus); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.ExpenseStatusChanged, map[string]interface{}{
+		"expense_id": expenseID,
+		"from":       fromStatus,
+		"to":         status,
+	})
+
+	return s.er.GetExpenseByID(ctx, missionID, expenseID)
+}
+
+// GetExpenseReceipt returns the location of the receipt file on disk
+func (s *Service) GetExpenseReceipt(ctx context.Context, missionID, expenseID uuid.UUID) (string, error) {
+	const op = "service.GetExpenseReceipt"
+
+	exp, err := s.er.GetExpenseByID(ctx, missionID, expenseID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if exp.ReceiptPath == nil {
+		return "", utils.ErrReceiptNotFound
+	}
+
+	return filepath.Join(s.cfg.Expenses.ReceiptDir, *exp.ReceiptPath), nil
+}
+
+// checkClaimable only the assigned cat can claim, and finished missions accept claims until the grace period runs out
+func (s *Service) checkClaimable(mis *mission.Mission, catID *uuid.UUID, now time.Time) error {
+	if mis.CatID == nil || (catID != nil && *catID != *mis.CatID) {
+		return utils.ErrExpenseClaimant
+	}
+
+	if mis.State != startedState {
+		finishedAt := mis.UpdatedAt
+		if mis.FinishedAt != nil {
+			finishedAt = *mis.FinishedAt
+		}
+
+		if now.After(finishedAt.Add(s.cfg.Expenses.GracePeriod)) {
+			return utils.ErrExpenseClosed
+		}
+	}
+
+	return nil
+}
+
+// storeReceipt writes the receipt under a generated name, the returned path is relative to the receipts directory
+func (s *Service) storeReceipt(missionID uuid.UUID, receipt *ReceiptSvc) (string, error) {
+	relPath := filepath.Join(missionID.String(), uuid.NewString()+strings.ToLower(filepath.Ext(receipt.Filename)))
+	fullPath := filepath.Join(s.cfg.Expenses.ReceiptDir, relPath)
+
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return "", err
+	}
+
+	file, err := os.OpenFile(fullPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", err
+	}
+
+	// one extra byte tells an oversized file apart from one exactly at the limit
+	written, err := io.Copy(file, io.LimitReader(receipt.Content, s.cfg.Expenses.MaxReceiptBytes+1))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil && written > s.cfg.Expenses.MaxReceiptBytes {
+		err = utils.ErrReceiptTooLarge
+	}
+
+	if err != nil {
+		_ = os.Remove(fullPath)
+		return "", err
+	}
+
+	return relPath, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AddJournalEntry appends an observation to the target journal, the caller is recorded as the author
+func (s *Service) AddJournalEntry(ctx context.Context, missionID, targetID uuid.UUID, req target.CreateJournalEntrySvc) (uuid.UUID, error) {
+	const op = "service.AddJournalEntry"
+
+	if _, _, err := s.writableTarget(ctx, missionID, targetID); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry := target.NewJournalEntry(targetID, event.ActorFromCtx(ctx), req, time.Now())
+	if err := entry.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.tr.AddJournalEntry(ctx, entry)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.JournalEntryAdded, map[string]interface{}{
+		"target_id":  targetID,
+		"entry_id":   id,
+		"confidence": entry.Confidence,
+	})
+
+	return id, nil
+}
+
+func (s *Service) ListJournalEntries(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.JournalEntry, error) {
+	const op = "service.ListJournalEntries"
+
+	if _, _, err := s.missionTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := s.tr.GetJournalEntries(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+// UpdateJournalEntry corrects an entry, entries are frozen once the target is completed
+func (s *Service) UpdateJournalEntry(ctx context.Context, missionID, targetID, entryID uuid.UUID, params target.UpdateJournalEntryParams) (*target.JournalEntry, error) {
+	const op = "service.UpdateJournalEntry"
+
+	if _, _, err := s.writableTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry, err := s.tr.GetJournalEntry(ctx, targetID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entry.Update(params)
+	if err = entry.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.tr.UpdateJournalEntry(ctx, entry); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.JournalEntryEdited, map[string]interface{}{
+		"target_id": targetID,
+		"entry_id":  entryID,
+	})
+
+	return s.tr.GetJournalEntry(ctx, targetID, entryID)
+}
+
+// SummarizeTargetNotes replaces the notes with a summary of the journal, stored as a new notes revision
+func (s *Service) SummarizeTargetNotes(ctx context.Context, missionID, targetID uuid.UUID) (*target.NotesRevision, error) {
+	const op = "service.SummarizeTargetNotes"
+
+	if _, _, err := s.writableTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	entries, err := s.tr.GetJournalEntries(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revision, err := s.tr.UpdateTargetNotes(ctx, missionID, targetID, target.Summarize(entries), event.ActorFromCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.NotesSummarized, map[string]interface{}{
+		"target_id": targetID,
+		"revision":  revision,
+		"entries":   len(entries),
+	})
+
+	return s.tr.GetNotesRevision(ctx, missionID, targetID, revision)
+}
+
+// writableTarget fetches the target for a change, the journal and the sightings follow the same rules as the notes
+func (s *Service) writableTarget(ctx context.Context, missionID, targetID uuid.UUID) (*mission.Mission, *target.Target, error) {
+	mis, tar, err := s.missionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return nil, nil, err
+	}
+
+	if err = tar.CheckEditable(); err != nil {
+		return nil, nil, err
+	}
+
+	return mis, tar, nil
+}
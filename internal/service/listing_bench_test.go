@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/encryption"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	database "github.com/PureTeamLead/go-test-assessment-developstoday/pkg/storage/postgres"
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"testing"
+)
+
+const (
+	benchMissions          = 50
+	benchTargetsPerMission = 3
+)
+
+// BenchmarkListMissions compares the lookups of a page of missions with their targets and cats: the previous
+// per-mission path (the page query without the cat join, then a mission, targets and cat lookup per mission)
+// against the set-based one (the joined page query and one query for the targets of the page). It needs
+// a Postgres configured through the POSTGRES_* variables and is skipped otherwise
+func BenchmarkListMissions(b *testing.B) {
+	ctx := context.Background()
+
+	var dbCfg database.PostgresConfig
+	if err := cleanenv.ReadEnv(&dbCfg); err != nil || dbCfg.Host == "" {
+		b.Skip("POSTGRES_HOST is not set")
+	}
+	dbCfg.MigPath = "../../db/migrations"
+	if dbCfg.MaxConns == 0 {
+		dbCfg.MaxConns = 4
+	}
+
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	key := make([]byte, 32)
+	if _, err = rand.Read(key); err != nil {
+		b.Fatal(err)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(key)
+	keys, err := encryption.NewKeyring(encryption.Config{Keys: "bench:" + encoded, ActiveKeyID: "bench", IndexKey: encoded})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	mr, tr := mission.NewRepository(db), target.NewRepository(db, keys)
+
+	missionIDs, catIDs := seedMissions(ctx, b, mr, tr, cat.NewRepository(db))
+	defer cleanupMissions(ctx, b, db, missionIDs, catIDs)
+
+	spec := mission.QuerySpec{Limit: benchMissions}
+	if err = spec.Validate(); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("per-mission", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, id := range pageMissionIDs(ctx, b, db) {
+				if _, err := mr.GetMissionByID(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := tr.GetTargetsByMissionID(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+
+				if _, err := mr.GetAssignedCat(ctx, id); err != nil && !errors.Is(err, utils.ErrCatNotFound) {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("set-based", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			page, err := mr.GetMissions(ctx, spec)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			missionIDs := make([]uuid.UUID, 0, len(page.Missions))
+			for _, mis := range page.Missions {
+				missionIDs = append(missionIDs, mis.ID)
+			}
+
+			if _, err = tr.GetTargetsByMissionIDs(ctx, missionIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// pageMissionIDs is the page query of the per-mission path, it reads the mission rows without joining their cats
+func pageMissionIDs(ctx context.Context, b *testing.B, db *pgxpool.Pool) []uuid.UUID {
+	rows, err := db.Query(ctx, "SELECT id FROM missions WHERE deleted_at IS NULL ORDER BY created_at, id LIMIT $1", benchMissions+1)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0, benchMissions)
+	for rows.Next() {
+		var id uuid.UUID
+		if err = rows.Scan(&id); err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		b.Fatal(err)
+	}
+
+	if len(ids) > benchMissions {
+		ids = ids[:benchMissions]
+	}
+
+	return ids
+}
+
+func seedMissions(ctx context.Context, b *testing.B, mr *mission.Repository, tr *target.Repository, cr *cat.Repository) ([]uuid.UUID, []uuid.UUID) {
+	b.Helper()
+
+	missionIDs := make([]uuid.UUID, 0, benchMissions)
+	catIDs := make([]uuid.UUID, 0, benchMissions)
+
+	for i := 0; i < benchMissions; i++ {
+		missionID, err := mr.AddMission(ctx, &mission.Mission{ApprovalStatus: mission.ApprovalApproved}, nil, "bench")
+		if err != nil {
+			b.Fatal(err)
+		}
+		missionIDs = append(missionIDs, missionID)
+
+		catID, err := cr.AddCat(ctx, &cat.Cat{Name: "bench-" + uuid.NewString(), YearsXP: 3, Breed: "Siamese", SalaryCents: 100000})
+		if err != nil {
+			b.Fatal(err)
+		}
+		catIDs = append(catIDs, catID)
+
+		if err = mr.AddCatID(ctx, missionID, catID); err != nil {
+			b.Fatal(err)
+		}
+
+		for j := 0; j < benchTargetsPerMission; j++ {
+			tar := &target.Target{Name: fmt.Sprintf("bench target %d-%d", i, j), Country: "FR", Notes: "benchmark notes"}
+			if _, err = tr.AddTarget(ctx, missionID, tar, "bench"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	return missionIDs, catIDs
+}
+
+func cleanupMissions(ctx context.Context, b *testing.B, db *pgxpool.Pool, missionIDs, catIDs []uuid.UUID) {
+	if _, err := db.Exec(ctx, "DELETE FROM targets WHERE mission_id = ANY($1)", missionIDs); err != nil {
+		b.Error(err)
+	}
+
+	if _, err := db.Exec(ctx, "DELETE FROM missions WHERE id = ANY($1)", missionIDs); err != nil {
+		b.Error(err)
+	}
+
+	if _, err := db.Exec(ctx, "DELETE FROM cats WHERE id = ANY($1)", catIDs); err != nil {
+		b.Error(err)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// MoveTarget reassigns an open target to another open mission, the move shows up in both timelines
+func (s *Service) MoveTarget(ctx context.Context, missionID, targetID, destMissionID uuid.UUID) error {
+	const op = "service.MoveTarget"
+
+	if missionID == destMissionID {
+		return utils.ErrSameMission
+	}
+
+	_, tar, err := s.missionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = s.mr.GetMissionByID(ctx, destMissionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	payload := map[string]interface{}{
+		"target_id":       targetID,
+		"name":            tar.Name,
+		"from_mission_id": missionID,
+		"to_mission_id":   destMissionID,
+	}
+	actor := event.ActorFromCtx(ctx)
+
+	// the events and the approval status of both missions are settled in the move transaction,
+	// so a moved target can not leave the destination approved with fewer approvals than it now needs
+	hook := func(ctx context.Context, tx pgx.Tx, countries map[uuid.UUID][]string) error {
+		for _, id := range []uuid.UUID{missionID, destMissionID} {
+			if err := s.evr.AddEventTx(ctx, tx, event.NewEntity(id, event.TargetMoved, actor, payload)); err != nil {
+				return err
+			}
+
+			required := s.requiredApprovals(countries[id])
+
+			status, changed, err := s.mr.RecheckApprovalTx(ctx, tx, id, required)
+			if err != nil {
+				return err
+			}
+
+			if changed {
+				ev := event.NewEntity(id, event.ApprovalRechecked, actor, map[string]interface{}{
+					"required":        required,
+					"approval_status": status,
+				})
+				if err = s.evr.AddEventTx(ctx, tx, ev); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	}
+
+	if err = s.tr.MoveTarget(ctx, targetID, missionID, destMissionID, mission.MissionSize, hook); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
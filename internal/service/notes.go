@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+)
+
+// GetNotesHistory lists every revision of the target notes, the oldest first
+func (s *Service) GetNotesHistory(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.NotesRevision, error) {
+	const op = "service.GetNotesHistory"
+
+	if _, _, err := s.missionTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	revisions, err := s.tr.GetNotesRevisions(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revisions, nil
+}
+
+func (s *Service) DiffNotesRevisions(ctx context.Context, missionID, targetID uuid.UUID, from, to int) (*target.NotesDiff, error) {
+	const op = "service.DiffNotesRevisions"
+
+	if from <= 0 || to <= 0 {
+		return nil, utils.ErrInvalidQuery
+	}
+
+	if _, _, err := s.missionTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fromRevision, err := s.tr.GetNotesRevision(ctx, missionID, targetID, from)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	toRevision, err := s.tr.GetNotesRevision(ctx, missionID, targetID, to)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return target.NewNotesDiff(fromRevision, toRevision), nil
+}
+
+// RevertTargetNotes restores the content of an earlier revision as a new revision, so the history is kept intact
+func (s *Service) RevertTargetNotes(ctx context.Context, missionID, targetID uuid.UUID, revision int) (*target.NotesRevision, error) {
+	const op = "service.RevertTargetNotes"
+
+	mis, tar, err := s.missionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	if err = tar.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	source, err := s.tr.GetNotesRevision(ctx, missionID, targetID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	newRevision, err := s.tr.UpdateTargetNotes(ctx, missionID, targetID, source.Content, event.ActorFromCtx(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.NotesReverted, map[string]interface{}{
+		"target_id":   targetID,
+		"reverted_to": revision,
+		"revision":    newRevision,
+	})
+
+	return s.tr.GetNotesRevision(ctx, missionID, targetID, newRevision)
+}
+
+// missionTarget fetches the target only if it belongs to the mission
+func (s *Service) missionTarget(ctx context.Context, missionID, targetID uuid.UUID) (*mission.Mission, *target.Target, error) {
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tar, err := s.tr.GetMissionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return mis, tar, nil
+}
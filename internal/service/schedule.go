@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/schedule"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"time"
+)
+
+func (s *Service) CreateSchedule(ctx context.Context, req schedule.CreateScheduleSvc) (uuid.UUID, error) {
+	const op = "service.CreateSchedule"
+
+	sch := schedule.NewEntity(req)
+	if err := sch.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := sch.Plan(time.Now()); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.sr.AddSchedule(ctx, sch)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (s *Service) ListSchedules(ctx context.Context) ([]*schedule.Schedule, error) {
+	const op = "service.ListSchedules"
+
+	schedules, err := s.sr.GetSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return schedules, nil
+}
+
+// SetSchedulePaused pauses or resumes the schedule, pausing an already paused schedule is a no-op
+func (s *Service) SetSchedulePaused(ctx context.Context, id uuid.UUID, paused bool) (*schedule.Schedule, error) {
+	const op = "service.SetSchedulePaused"
+
+	sch, err := s.sr.GetScheduleByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if sch.Paused == paused {
+		return sch, nil
+	}
+
+	if paused {
+		sch.Paused = true
+	} else if err = sch.Resume(time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.sr.UpdateScheduleState(ctx, sch); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sch, nil
+}
+
+func (s *Service) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	const op = "service.DeleteSchedule"
+
+	if err := s.sr.DeleteSchedule(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RunDueSchedules materializes every due occurrence into a mission and returns the created mission ids.
+// An occurrence is claimed before its mission is created, so a restart or a second instance never creates
+// it twice; a failed creation or a claim left by a crashed run is retried on the next runs until the
+// occurrence is given up
+func (s *Service) RunDueSchedules(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	const op = "service.RunDueSchedules"
+
+	schedules, err := s.sr.GetDueSchedules(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	created := make([]uuid.UUID, 0, len(schedules))
+	for _, sch := range schedules {
+		id, err := s.runOccurrence(ctx, sch, now)
+		if err != nil {
+			logger.GetLoggerFromCtx(ctx).Error(op, err, zap.String("schedule_id", sch.ID.String()))
+			continue
+		}
+
+		if id != nil {
+			created = append(created, *id)
+		}
+	}
+
+	return created, nil
+}
+
+// runOccurrence returns nil id when the occurrence had already been materialized
+func (s *Service) runOccurrence(ctx context.Context, sch *schedule.Schedule, now time.Time) (*uuid.UUID, error) {
+	const op = "service.runOccurrence"
+
+	occurrence := *sch.NextRunAt
+	if err := sch.Advance(occurrence, now); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	claim, err := s.sr.ClaimOccurrence(ctx, sch.ID, occurrence, s.cfg.Schedules.ClaimTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a claim held by another run stays due, the schedule moves on only once the occurrence is settled
+	if !claim.Acquired {
+		if !claim.Settled {
+			return nil, nil
+		}
+
+		if err = s.sr.FinishOccurrence(ctx, sch, occurrence, nil); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil, nil
+	}
+
+	// an earlier run may have stopped after its mission was stored, that mission is adopted instead of creating another
+	adopted, err := s.adoptOccurrence(ctx, sch, occurrence)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if adopted {
+		return nil, nil
+	}
+
+	// the earlier attempts crashed before they could record a failure
+	if claim.Attempts > s.cfg.Schedules.MaxAttempts {
+		return nil, s.giveUpOccurrence(ctx, sch, occurrence, claim.Attempts-1, "runs stopped before the mission was created")
+	}
+
+	rawTargets := make([]CreateUpdateTargetSvc, 0, len(sch.Targets))
+	for _, skeleton := range sch.Targets {
+		rawTargets = append(rawTargets, CreateUpdateTargetSvc{Name: skeleton.Name, Country: skeleton.Country, Notes: skeleton.Notes})
+	}
+
+	startedAt := occurrence
+	id, err := s.createMission(ctx, CreateMissionSvc{
+		Targets:      rawTargets,
+		Type:         sch.MissionType,
+		Deadline:     sch.Deadline(occurrence),
+		StartedAt:    &startedAt,
+		BudgetCents:  sch.BudgetCents,
+		scheduleID:   &sch.ID,
+		occurrenceAt: &startedAt,
+	}, map[string]interface{}{"schedule_id": sch.ID, "occurrence_at": occurrence})
+	if errors.Is(err, utils.ErrConflictingData) {
+		// a run that took the claim over meanwhile stored the mission first
+		if _, err = s.adoptOccurrence(ctx, sch, occurrence); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return nil, nil
+	}
+
+	if err != nil {
+		if claim.Attempts >= s.cfg.Schedules.MaxAttempts {
+			return nil, s.giveUpOccurrence(ctx, sch, occurrence, claim.Attempts, err.Error())
+		}
+
+		if failErr := s.sr.FailOccurrence(ctx, sch.ID, occurrence, err.Error(), false); failErr != nil {
+			logger.GetLoggerFromCtx(ctx).Error(op, failErr, zap.String("schedule_id", sch.ID.String()))
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.sr.FinishOccurrence(ctx, sch, occurrence, &id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.autoAssignOccurrence(ctx, sch, id)
+
+	return &id, nil
+}
+
+// adoptOccurrence settles the occurrence with the mission already stored for it, if there is one
+func (s *Service) adoptOccurrence(ctx context.Context, sch *schedule.Schedule, occurrence time.Time) (bool, error) {
+	const op = "service.adoptOccurrence"
+
+	mis, err := s.mr.GetMissionByOccurrence(ctx, sch.ID, occurrence)
+	if err != nil {
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.sr.FinishOccurrence(ctx, sch, occurrence, &mis.ID); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if mis.CatID == nil && mis.DeletedAt == nil {
+		s.autoAssignOccurrence(ctx, sch, mis.ID)
+	}
+
+	return true, nil
+}
+
+// autoAssignOccurrence the mission exists regardless, so a missing approval or no free cat is not an error of the schedule
+func (s *Service) autoAssignOccurrence(ctx context.Context, sch *schedule.Schedule, missionID uuid.UUID) {
+	const op = "service.autoAssignOccurrence"
+
+	if !sch.AutoAssign {
+		return
+	}
+
+	if _, err := s.AutoAssignCat(ctx, missionID, false); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err, zap.String("mission_id", missionID.String()))
+	}
+}
+
+// giveUpOccurrence records why the occurrence failed for good and moves the schedule past it,
+// so one broken occurrence doesn't block the next ones
+func (s *Service) giveUpOccurrence(ctx context.Context, sch *schedule.Schedule, occurrence time.Time, attempts int, reason string) error {
+	const op = "service.giveUpOccurrence"
+
+	if err := s.sr.FailOccurrence(ctx, sch.ID, occurrence, reason, true); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.sr.FinishOccurrence(ctx, sch, occurrence, nil); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return fmt.Errorf("%s: occurrence %s given up after %d attempts: %w", op, occurrence.Format(time.RFC3339), attempts, utils.ErrOccurrenceFailed)
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+)
+
+func (s *Service) SearchTargets(ctx context.Context, spec target.SearchSpec) ([]*target.SearchResult, error) {
+	const op = "service.SearchTargets"
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	results, err := s.tr.SearchTargets(ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
@@ -5,50 +5,164 @@ import (
 	"errors"
 	"fmt"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/cat"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/country"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/dossier"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/expense"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/schedule"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/template"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
 )
 
 type MissionRepository interface {
-	AddMission(ctx context.Context) (uuid.UUID, error)
+	AddMission(ctx context.Context, mission *mission.Mission, targets []*mission.NewTarget, author string) (uuid.UUID, error)
+	CloneMission(ctx context.Context, sourceID uuid.UUID, targets []*mission.NewTarget, author string) (uuid.UUID, error)
+	UpdateMission(ctx context.Context, mission *mission.Mission) error
 	DeleteMission(ctx context.Context, id uuid.UUID) error
 	SetMissionCompleted(ctx context.Context, id uuid.UUID) error
 	AddCatID(ctx context.Context, missionID uuid.UUID, catID uuid.UUID) error
-	GetMissions(ctx context.Context) ([]*mission.Mission, error)
+	GetMissions(ctx context.Context, spec mission.QuerySpec) (*mission.Page, error)
+	MarkOverdueMissions(ctx context.Context) ([]*mission.Mission, error)
 	GetMissionByCatID(ctx context.Context, catID uuid.UUID) (*mission.Mission, error)
 	GetMissionByID(ctx context.Context, id uuid.UUID) (*mission.Mission, error)
+	GetMissionByOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time) (*mission.Mission, error)
+	AbortMission(ctx context.Context, id uuid.UUID, reason, debrief string) error
+	AddDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error
+	RemoveDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error
+	GetOpenPrerequisites(ctx context.Context, missionID uuid.UUID) ([]*mission.Mission, error)
+	GetDependents(ctx context.Context, prerequisiteID uuid.UUID) ([]*mission.Mission, error)
+	GetTrashedMissions(ctx context.Context) ([]*mission.Mission, error)
+	RestoreMission(ctx context.Context, id uuid.UUID) error
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	DecideApproval(ctx context.Context, approval *mission.Approval, required int) (string, error)
+	SetApprovalStatus(ctx context.Context, id uuid.UUID, status string) error
+	RecheckApproval(ctx context.Context, id uuid.UUID, required int) (string, bool, error)
+	RecheckApprovalTx(ctx context.Context, tx pgx.Tx, id uuid.UUID, required int) (string, bool, error)
+	GetApprovals(ctx context.Context, missionID uuid.UUID) ([]*mission.Approval, error)
 	GetAssignedCat(ctx context.Context, missionID uuid.UUID) (*cat.Cat, error)
+	GetAssignmentCandidates(ctx context.Context, countries []string, since time.Time) ([]*mission.Candidate, error)
+	GetAssignedMissionsActiveBetween(ctx context.Context, from, to time.Time) ([]*mission.SalaryBasis, error)
 }
 
 type TargetRepository interface {
 	GetTargetsByMissionID(ctx context.Context, missionID uuid.UUID) ([]*target.Target, error)
+	GetTargetsByMissionIDs(ctx context.Context, missionIDs []uuid.UUID) (map[uuid.UUID][]*target.Target, error)
 	GetTargetByID(ctx context.Context, id uuid.UUID) (*target.Target, error)
-	UpdateTargetNotes(ctx context.Context, id uuid.UUID, notes string) error
-	SetTargetCompleted(ctx context.Context, id uuid.UUID) error
-	AddTarget(ctx context.Context, missionID uuid.UUID, target *target.Target) (uuid.UUID, error)
-	DeleteTarget(ctx context.Context, id uuid.UUID) error
+	GetMissionTarget(ctx context.Context, missionID, id uuid.UUID) (*target.Target, error)
+	UpdateTargetNotes(ctx context.Context, missionID, id uuid.UUID, notes, author string) (int, error)
+	UpdateTargetState(ctx context.Context, target *target.Target, fromState string) error
+	AddTarget(ctx context.Context, missionID uuid.UUID, target *target.Target, author string) (uuid.UUID, error)
+	SealInitialNotes(targetID uuid.UUID, notes string) (*target.InitialNotes, error)
+	GetNotesRevisions(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.NotesRevision, error)
+	GetNotesRevision(ctx context.Context, missionID, targetID uuid.UUID, revision int) (*target.NotesRevision, error)
+	AddJournalEntry(ctx context.Context, entry *target.JournalEntry) (uuid.UUID, error)
+	GetJournalEntries(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.JournalEntry, error)
+	GetJournalEntry(ctx context.Context, targetID, id uuid.UUID) (*target.JournalEntry, error)
+	UpdateJournalEntry(ctx context.Context, entry *target.JournalEntry) error
+	AddSighting(ctx context.Context, sighting *target.Sighting) (uuid.UUID, error)
+	GetSightings(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.Sighting, error)
+	GetSightingsByMissionID(ctx context.Context, missionID uuid.UUID) ([]*target.Sighting, error)
+	LinkDossier(ctx context.Context, id, dossierID uuid.UUID) error
+	UnlinkDossier(ctx context.Context, id, dossierID uuid.UUID) error
+	MoveTarget(ctx context.Context, id, fromMissionID, toMissionID uuid.UUID, limit int, hook target.MoveHook) error
+	GetTargetsByDossierID(ctx context.Context, dossierID uuid.UUID) ([]*target.Target, error)
+	SearchTargets(ctx context.Context, spec target.SearchSpec) ([]*target.SearchResult, error)
+	DeleteTarget(ctx context.Context, missionID, id uuid.UUID) error
+}
+
+type TemplateRepository interface {
+	GetTemplateByID(ctx context.Context, id uuid.UUID) (*template.Template, error)
+}
+
+type ExpenseRepository interface {
+	AddExpense(ctx context.Context, expense *expense.Expense) (uuid.UUID, error)
+	GetExpenseByID(ctx context.Context, missionID, id uuid.UUID) (*expense.Expense, error)
+	GetExpensesByMissionID(ctx context.Context, missionID uuid.UUID) ([]*expense.Expense, error)
+	GetExpensesBetween(ctx context.Context, from, to time.Time, statuses []string) ([]*expense.Expense, error)
+	SumByMissionIDs(ctx context.Context, missionIDs []uuid.UUID, statuses []string) (map[uuid.UUID]map[string]int64, error)
+	UpdateExpenseStatus(ctx context.Context, expense *expense.Expense, fromStatus string) error
+}
+
+type EventRepository interface {
+	AddEvent(ctx context.Context, event *event.Event) error
+	AddEventTx(ctx context.Context, tx pgx.Tx, event *event.Event) error
+	GetMissionEvents(ctx context.Context, missionID uuid.UUID, cursor *mission.Cursor, limit int) (*event.Page, error)
+	GetEventsByMissionIDs(ctx context.Context, missionIDs []uuid.UUID) ([]*event.Event, error)
+}
+
+type DossierRepository interface {
+	AddDossier(ctx context.Context, dossier *dossier.Dossier) (uuid.UUID, error)
+	GetDossierByID(ctx context.Context, id uuid.UUID) (*dossier.Dossier, error)
+	GetDossiers(ctx context.Context) ([]*dossier.Dossier, error)
+	GetMatchCandidates(ctx context.Context, name, countryCode string) ([]*dossier.Dossier, error)
+}
+
+type ScheduleRepository interface {
+	AddSchedule(ctx context.Context, schedule *schedule.Schedule) (uuid.UUID, error)
+	GetScheduleByID(ctx context.Context, id uuid.UUID) (*schedule.Schedule, error)
+	GetSchedules(ctx context.Context) ([]*schedule.Schedule, error)
+	GetDueSchedules(ctx context.Context, now time.Time) ([]*schedule.Schedule, error)
+	UpdateScheduleState(ctx context.Context, schedule *schedule.Schedule) error
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+	ClaimOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time, staleAfter time.Duration) (*schedule.Claim, error)
+	FailOccurrence(ctx context.Context, scheduleID uuid.UUID, occurrence time.Time, reason string, giveUp bool) error
+	FinishOccurrence(ctx context.Context, schedule *schedule.Schedule, occurrence time.Time, missionID *uuid.UUID) error
 }
 
 type Service struct {
-	mr MissionRepository
-	tr TargetRepository
+	mr  MissionRepository
+	tr  TargetRepository
+	tmr TemplateRepository
+	er  ExpenseRepository
+	evr EventRepository
+	sr  ScheduleRepository
+	dr  DossierRepository
+	cfg Config
 }
 
-const completedState = "completed"
+const (
+	startedState   = "started"
+	completedState = "completed"
+	abortedState   = "aborted"
+)
 
-func New(mr MissionRepository, tr TargetRepository) *Service {
-	return &Service{mr: mr, tr: tr}
+func New(mr MissionRepository, tr TargetRepository, tmr TemplateRepository, er ExpenseRepository, evr EventRepository, sr ScheduleRepository, dr DossierRepository, cfg Config) *Service {
+	return &Service{mr: mr, tr: tr, tmr: tmr, er: er, evr: evr, sr: sr, dr: dr, cfg: cfg}
 }
 
-func (s *Service) CreateMission(ctx context.Context, rawTargets []CreateUpdateTargetSvc) (uuid.UUID, error) {
+func (s *Service) CreateMission(ctx context.Context, req CreateMissionSvc) (uuid.UUID, error) {
+	return s.createMission(ctx, req, nil)
+}
+
+// createMission origin is merged into the payload of the creation event
+func (s *Service) createMission(ctx context.Context, req CreateMissionSvc, origin map[string]interface{}) (uuid.UUID, error) {
 	const op = "service.CreateMission"
 
+	rawTargets := req.Targets
 	if len(rawTargets) == 0 {
 		return uuid.Nil, utils.ErrNoTargets
 	}
 
+	newMission := mission.NewEntity(req.Type, req.Deadline, req.StartedAt, req.BudgetCents)
+	newMission.ScheduleID, newMission.OccurrenceAt = req.scheduleID, req.occurrenceAt
+	if err := newMission.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	countries := make([]string, 0, len(rawTargets))
+	for _, rawTarget := range rawTargets {
+		countries = append(countries, rawTarget.Country)
+	}
+
+	if s.requiredApprovals(countries) == 0 {
+		newMission.ApprovalStatus = mission.ApprovalApproved
+	}
+
 	var validatedTargets []*target.Target
 	for _, rawTarget := range rawTargets {
 		notes := ""
@@ -68,185 +182,490 @@ func (s *Service) CreateMission(ctx context.Context, rawTargets []CreateUpdateTa
 		return uuid.Nil, utils.ErrValidatingTargets
 	}
 
-	id, err := s.mr.AddMission(ctx)
+	newTargets := make([]*mission.NewTarget, 0, len(validatedTargets))
+	targetNames := make([]string, 0, len(validatedTargets))
+	for _, validTarget := range validatedTargets {
+		newTarget, err := s.newMissionTarget(validTarget.Name, validTarget.Country, validTarget.Notes)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		newTargets = append(newTargets, newTarget)
+		targetNames = append(targetNames, validTarget.Name)
+	}
+
+	id, err := s.mr.AddMission(ctx, newMission, newTargets, event.ActorFromCtx(ctx))
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, validTarget := range validatedTargets {
-		if _, err = s.tr.AddTarget(ctx, id, validTarget); err != nil {
-			return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	payload := map[string]interface{}{"mission_type": req.Type, "targets": targetNames}
+	for key, value := range origin {
+		payload[key] = value
+	}
+	s.record(ctx, id, event.MissionCreated, payload)
+
+	return id, nil
+}
+
+// CreateMissionFromTemplate copies the template targets applying overrides and creates the mission out of them
+func (s *Service) CreateMissionFromTemplate(ctx context.Context, templateID uuid.UUID, req CreateFromTemplateSvc) (uuid.UUID, error) {
+	const op = "service.CreateMissionFromTemplate"
+
+	tmpl, err := s.tmr.GetTemplateByID(ctx, templateID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for name := range req.Overrides {
+		if !tmpl.HasTarget(name) {
+			return uuid.Nil, utils.ErrUnknownOverride
 		}
+	}
+
+	rawTargets := make([]CreateUpdateTargetSvc, 0, len(tmpl.Targets))
+	for _, skeleton := range tmpl.Targets {
+		rawTarget := CreateUpdateTargetSvc{Name: skeleton.Name, Country: skeleton.Country, Notes: skeleton.Notes}
+		if rawTarget.Notes == nil && tmpl.DefaultNotes != "" {
+			defaultNotes := tmpl.DefaultNotes
+			rawTarget.Notes = &defaultNotes
+		}
+
+		if override, ok := req.Overrides[skeleton.Name]; ok {
+			if override.Name != nil {
+				rawTarget.Name = *(override.Name)
+			}
+
+			if override.Country != nil {
+				rawTarget.Country = *(override.Country)
+			}
 
+			if override.Notes != nil {
+				rawTarget.Notes = override.Notes
+			}
+		}
+
+		rawTargets = append(rawTargets, rawTarget)
+	}
+
+	id, err := s.createMission(ctx, CreateMissionSvc{
+		Targets:   rawTargets,
+		Type:      tmpl.MissionType,
+		Deadline:  req.Deadline,
+		StartedAt: req.StartedAt,
+	}, map[string]interface{}{"template_id": templateID})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return id, nil
 }
 
-func (s *Service) DeleteMission(ctx context.Context, id uuid.UUID) error {
-	const op = "service.DeleteMission"
+// CloneMission re-runs the mission: targets are copied with reset states into a new mission with no cat assigned.
+// Sealed notes only open for their own target, so the copied notes are sealed again for the new targets
+func (s *Service) CloneMission(ctx context.Context, id uuid.UUID, withNotes bool) (uuid.UUID, error) {
+	const op = "service.CloneMission"
 
-	targets, err := s.tr.GetTargetsByMissionID(ctx, id)
+	sources, err := s.tr.GetTargetsByMissionID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	clones := make([]*mission.NewTarget, 0, len(sources))
+	for _, source := range sources {
+		notes := ""
+		if withNotes {
+			notes = source.Notes
+		}
+
+		clone, err := s.newMissionTarget(source.Name, source.Country, notes)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		clones = append(clones, clone)
+	}
+
+	cloneID, err := s.mr.CloneMission(ctx, id, clones, event.ActorFromCtx(ctx))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	for _, tar := range targets {
-		if err = s.tr.DeleteTarget(ctx, tar.ID); err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+	// the clone goes through approval again unless its targets need none
+	targets, err := s.tr.GetTargetsByMissionID(ctx, cloneID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.requiredApprovals(targetCountries(targets)) == 0 {
+		if err = s.mr.SetApprovalStatus(ctx, cloneID, mission.ApprovalApproved); err != nil {
+			return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	err = s.mr.DeleteMission(ctx, id)
+	s.record(ctx, cloneID, event.MissionCreated, map[string]interface{}{"cloned_from": id, "with_notes": withNotes})
+
+	return cloneID, nil
+}
+
+// newMissionTarget prepares a target stored together with its mission, the notes are sealed for the new target ID
+func (s *Service) newMissionTarget(name, country, notes string) (*mission.NewTarget, error) {
+	newTarget := &mission.NewTarget{ID: uuid.New(), Name: name, Country: country}
+
+	sealed, err := s.tr.SealInitialNotes(newTarget.ID, notes)
 	if err != nil {
+		return nil, err
+	}
+
+	newTarget.Notes, newTarget.NotesKeyID, newTarget.NotesIndex = sealed.Notes.Value, sealed.Notes.KeyID, sealed.Notes.Index
+	newTarget.Revision, newTarget.RevisionKeyID = sealed.Revision, sealed.RevisionKeyID
+
+	return newTarget, nil
+}
+
+// DeleteMission moves the mission with its targets into the trash, it is purged once the retention period is over
+func (s *Service) DeleteMission(ctx context.Context, id uuid.UUID) error {
+	const op = "service.DeleteMission"
+
+	if err := s.mr.DeleteMission(ctx, id); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.record(ctx, id, event.MissionDeleted, nil)
+	s.failDependents(ctx, id, "deleted")
+
 	return nil
 }
 
 func (s *Service) UpdateMissionState(ctx context.Context, id uuid.UUID) error {
 	const op = "service.UpdateMissionState"
 
-	err := s.mr.SetMissionCompleted(ctx, id)
+	mis, err := s.mr.GetMissionByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if mis.State == abortedState {
+		return utils.ErrMissionAborted
+	}
+
+	if err = s.checkPrerequisites(ctx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.mr.SetMissionCompleted(ctx, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.record(ctx, id, event.MissionCompleted, nil)
+
+	s.completeDependents(ctx, id)
+
 	return nil
 }
 
-func (s *Service) SetMissionTargetState(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID) error {
-	const op = "service.SetMissionTargetState"
+// AbortMission stops the mission for good, open targets are frozen as abandoned and the cat is released
+func (s *Service) AbortMission(ctx context.Context, id uuid.UUID, reason, debrief string) (*FullMission, error) {
+	const op = "service.AbortMission"
 
-	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err := mission.ValidateAbortReason(reason); err != nil {
+		return nil, err
+	}
+
+	mis, err := s.mr.GetMissionByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if mis.State == completedState {
-		return utils.ErrMissionCompleted
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
 	}
 
-	if err = s.tr.SetTargetCompleted(ctx, targetID); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if err = s.mr.AbortMission(ctx, id, reason, debrief); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	s.record(ctx, id, event.MissionAborted, map[string]interface{}{"reason": reason, "debrief": debrief})
+	s.failDependents(ctx, id, abortedState)
+
+	return s.GetMission(ctx, id)
 }
 
-func (s *Service) UpdateMissionTargetNotes(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID, notes string) error {
-	const op = "service.SetMissionTargetState"
+func (s *Service) UpdateMission(ctx context.Context, params mission.UpdateMissionParams) (*FullMission, error) {
+	const op = "service.UpdateMission"
 
-	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	mis, err := s.mr.GetMissionByID(ctx, params.ID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if mis.State == completedState {
-		return utils.ErrMissionCompleted
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	mis.Update(params)
+	if err = mis.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	tar, err := s.tr.GetTargetByID(ctx, targetID)
+	if err = s.mr.UpdateMission(ctx, mis); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, mis.ID, event.MissionUpdated, missionChanges(params))
+
+	return s.GetMission(ctx, mis.ID)
+}
+
+// MarkOverdueMissions returns the missions that became overdue since the previous call
+func (s *Service) MarkOverdueMissions(ctx context.Context) ([]*mission.Mission, error) {
+	const op = "service.MarkOverdueMissions"
+
+	missions, err := s.mr.MarkOverdueMissions(ctx)
 	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, mis := range missions {
+		s.record(ctx, mis.ID, event.MissionOverdue, map[string]interface{}{"deadline": mis.Deadline})
+	}
+
+	return missions, nil
+}
+
+func (s *Service) SetMissionTargetState(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID) error {
+	const op = "service.SetMissionTargetState"
+
+	if _, err := s.SetTargetState(ctx, missionID, targetID, target.StateCompleted); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if tar.State == completedState {
-		return utils.ErrTargetCompleted
+	return nil
+}
+
+func (s *Service) UpdateMissionTargetNotes(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID, notes string) error {
+	const op = "service.UpdateMissionTargetNotes"
+
+	if _, _, err := s.writableTarget(ctx, missionID, targetID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = s.tr.UpdateTargetNotes(ctx, targetID, notes); err != nil {
+	revision, err := s.tr.UpdateTargetNotes(ctx, missionID, targetID, notes, event.ActorFromCtx(ctx))
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.record(ctx, missionID, event.NotesUpdated, map[string]interface{}{"target_id": targetID, "revision": revision})
+
 	return nil
 }
 
-func (s *Service) DeleteTargetFromMission(ctx context.Context, id uuid.UUID) error {
+func (s *Service) DeleteTargetFromMission(ctx context.Context, missionID, id uuid.UUID) error {
 	const op = "service.DeleteTargetsFromMission"
 
-	tar, err := s.tr.GetTargetByID(ctx, id)
+	// targets of a trashed mission must stay as they are until it is restored
+	_, tar, err := s.missionTarget(ctx, missionID, id)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if tar.State == completedState {
-		return utils.ErrTargetCompleted
+	if err = tar.CheckEditable(); err != nil {
+		return err
+	}
+
+	if err = s.tr.DeleteTarget(ctx, missionID, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = s.tr.DeleteTarget(ctx, id); err != nil {
+	s.record(ctx, missionID, event.TargetRemoved, map[string]interface{}{"target_id": id, "name": tar.Name})
+
+	if err = s.recheckApproval(ctx, missionID); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Service) AddTargetToMission(ctx context.Context, missionID uuid.UUID, tarReq CreateUpdateTargetSvc) error {
+func (s *Service) AddTargetToMission(ctx context.Context, missionID uuid.UUID, tarReq CreateUpdateTargetSvc) (uuid.UUID, error) {
 	const op = "service.AddTargetToMission"
 
 	mis, err := s.mr.GetMissionByID(ctx, missionID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	targets, err := s.tr.GetTargetsByMissionID(ctx, missionID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if len(targets) == mission.MissionSize {
-		return utils.ErrTargetOverflow
+		return uuid.Nil, utils.ErrTargetOverflow
 	}
 
-	if mis.State == completedState {
-		return utils.ErrMissionCompleted
+	if err = mis.CheckEditable(); err != nil {
+		return uuid.Nil, err
 	}
 
 	tar := MapTargetSvcToEntity(tarReq)
+	if err = tar.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	if _, err = s.tr.AddTarget(ctx, missionID, tar); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	targetID, err := s.tr.AddTarget(ctx, missionID, tar, event.ActorFromCtx(ctx))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	s.record(ctx, missionID, event.TargetAdded, map[string]interface{}{"target_id": targetID, "name": tar.Name})
+
+	if err = s.recheckApproval(ctx, missionID); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return targetID, nil
 }
 
 func (s *Service) AssignCatToMission(ctx context.Context, missionID uuid.UUID, catID uuid.UUID) error {
+	return s.assignCat(ctx, missionID, catID, false)
+}
+
+func (s *Service) assignCat(ctx context.Context, missionID uuid.UUID, catID uuid.UUID, auto bool) error {
 	const op = "service.AssignCatToMission"
 
-	if err := s.mr.AddCatID(ctx, missionID, catID); err != nil {
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if mis.State == abortedState {
+		return utils.ErrMissionAborted
+	}
+
+	if mis.ApprovalStatus != mission.ApprovalApproved {
+		return utils.ErrMissionNotApproved
+	}
+
+	if err = s.checkPrerequisites(ctx, missionID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.mr.AddCatID(ctx, missionID, catID); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.record(ctx, missionID, event.CatAssigned, map[string]interface{}{"cat_id": catID, "auto": auto})
+
 	return nil
 }
 
-func (s *Service) ListMissions(ctx context.Context) ([]*FullMission, error) {
+func (s *Service) ListMissions(ctx context.Context, spec mission.QuerySpec) (*MissionPage, error) {
 	const op = "service.ListMission"
 
-	missions, err := s.mr.GetMissions(ctx)
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if spec.Country != "" {
+		spec.Country = country.Code(spec.Country)
+	}
+
+	page, err := s.mr.GetMissions(ctx, spec)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	fullMissions := make([]*FullMission, 0, len(missions))
+	fullMissions, err := s.getFullMissions(ctx, page)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-	for _, mis := range missions {
-		var fullMis *FullMission
-		if fullMis, err = s.GetMission(ctx, mis.ID); err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+	return &MissionPage{Missions: fullMissions, NextCursor: page.NextCursor}, nil
+}
+
+// getFullMissions assembles the page in memory, the targets of all missions are fetched with a single query
+func (s *Service) getFullMissions(ctx context.Context, page *mission.Page) ([]*FullMission, error) {
+	missionIDs := make([]uuid.UUID, 0, len(page.Missions))
+	for _, mis := range page.Missions {
+		missionIDs = append(missionIDs, mis.ID)
+	}
+
+	targets, err := s.tr.GetTargetsByMissionIDs(ctx, missionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.er.SumByMissionIDs(ctx, missionIDs, expense.CountedStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	fullMissions := make([]*FullMission, 0, len(page.Missions))
+	for _, mis := range page.Missions {
+		fullMis := newFullMission(mis)
+		fullMis.Cat = page.Cats[mis.ID]
+
+		if missionTargets, ok := targets[mis.ID]; ok {
+			fullMis.Targets = missionTargets
 		}
 
+		fullMis.Approval = s.newApprovalInfo(mis, fullMis.Targets)
+
+		fullMis.Cost = s.missionCost(fullMis, mis, expenses[mis.ID])
+
 		fullMissions = append(fullMissions, fullMis)
 	}
 
 	return fullMissions, nil
 }
 
+// missionChanges lists only the fields the update actually set
+func missionChanges(params mission.UpdateMissionParams) map[string]interface{} {
+	changes := make(map[string]interface{})
+	if params.Deadline != nil {
+		changes["deadline"] = *params.Deadline
+	}
+
+	if params.StartedAt != nil {
+		changes["started_at"] = *params.StartedAt
+	}
+
+	if params.BudgetCents != nil {
+		changes["budget_cents"] = *params.BudgetCents
+	}
+
+	return changes
+}
+
+func newFullMission(mis *mission.Mission) *FullMission {
+	return &FullMission{
+		ID:          mis.ID,
+		Targets:     make([]*target.Target, 0),
+		Type:        mis.Type,
+		State:       mis.State,
+		Deadline:    mis.Deadline,
+		StartedAt:   mis.StartedAt,
+		Overdue:     mis.Overdue,
+		ClonedFrom:  mis.ClonedFrom,
+		BudgetCents: mis.BudgetCents,
+		Abort:       newAbortInfo(mis),
+		DeletedAt:   mis.DeletedAt,
+		CreatedAt:   mis.CreatedAt,
+		UpdatedAt:   mis.UpdatedAt,
+	}
+}
+
+func newAbortInfo(mis *mission.Mission) *AbortInfo {
+	if mis.AbortedAt == nil || mis.AbortReason == nil {
+		return nil
+	}
+
+	return &AbortInfo{Reason: *mis.AbortReason, Debrief: mis.AbortDebrief, AbortedAt: *mis.AbortedAt}
+}
+
 func (s *Service) GetMission(ctx context.Context, id uuid.UUID) (*FullMission, error) {
 	const op = "service.GetMission"
-	var fullMis FullMission
 
 	mis, err := s.mr.GetMissionByID(ctx, id)
 	if err != nil {
@@ -258,21 +677,39 @@ func (s *Service) GetMission(ctx context.Context, id uuid.UUID) (*FullMission, e
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	fullMis.ID = mis.ID
-	fullMis.State = mis.State
-	fullMis.CreatedAt = mis.CreatedAt
-	fullMis.UpdatedAt = mis.UpdatedAt
+	fullMis := newFullMission(mis)
 	fullMis.Targets = targets
 
+	fullMis.Approval = s.newApprovalInfo(mis, targets)
+	if fullMis.Approval.Decisions, err = s.mr.GetApprovals(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	assignedCat, err := s.mr.GetAssignedCat(ctx, id)
+	if err != nil && !errors.Is(err, utils.ErrCatNotFound) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	fullMis.Cat = assignedCat
+
+	expenses, err := s.er.SumByMissionIDs(ctx, []uuid.UUID{id}, expense.CountedStatuses)
 	if err != nil {
-		if !errors.Is(err, utils.ErrCatNotFound) {
-			return nil, fmt.Errorf("%s: %w", op, err)
-		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fullMis.Cost = s.missionCost(fullMis, mis, expenses[id])
+
+	blockedBy, err := s.mr.GetOpenPrerequisites(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-		return &fullMis, nil
+	blocks, err := s.mr.GetDependents(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	fullMis.Cat = assignedCat
-	return &fullMis, nil
+	fullMis.BlockedBy = newDependencyRefs(blockedBy)
+	fullMis.Blocks = newDependencyRefs(blocks)
+
+	return fullMis, nil
 }
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/geo"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/google/uuid"
+	"time"
+)
+
+// AddTargetSighting records where the target was seen, the source cat defaults to the cat assigned to the mission
+func (s *Service) AddTargetSighting(ctx context.Context, missionID, targetID uuid.UUID, req target.CreateSightingSvc) (uuid.UUID, error) {
+	const op = "service.AddTargetSighting"
+
+	mis, tar, err := s.writableTarget(ctx, missionID, targetID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.CatID == nil {
+		req.CatID = mis.CatID
+	}
+
+	sighting := target.NewSighting(targetID, req, time.Now())
+	if err = sighting.Validate(); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = sighting.CheckCountry(tar.Country); err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	id, err := s.tr.AddSighting(ctx, sighting)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, missionID, event.SightingAdded, map[string]interface{}{
+		"target_id":   targetID,
+		"sighting_id": id,
+		"cat_id":      sighting.CatID,
+		"latitude":    sighting.Latitude,
+		"longitude":   sighting.Longitude,
+	})
+
+	return id, nil
+}
+
+func (s *Service) ListTargetSightings(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.Sighting, error) {
+	const op = "service.ListTargetSightings"
+
+	if _, _, err := s.missionTarget(ctx, missionID, targetID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sightings, err := s.tr.GetSightings(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sightings, nil
+}
+
+// MissionSightingsGeoJSON collects the sightings of all mission targets as GeoJSON points
+func (s *Service) MissionSightingsGeoJSON(ctx context.Context, missionID uuid.UUID) (*geo.FeatureCollection, error) {
+	const op = "service.MissionSightingsGeoJSON"
+
+	if _, err := s.mr.GetMissionByID(ctx, missionID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	targets, err := s.tr.GetTargetsByMissionID(ctx, missionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	names := make(map[uuid.UUID]string, len(targets))
+	for _, tar := range targets {
+		names[tar.ID] = tar.Name
+	}
+
+	sightings, err := s.tr.GetSightingsByMissionID(ctx, missionID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	collection := geo.NewFeatureCollection()
+	for _, sighting := range sightings {
+		collection.AddPoint(sighting.Latitude, sighting.Longitude, map[string]interface{}{
+			"sighting_id": sighting.ID,
+			"mission_id":  missionID,
+			"target_id":   sighting.TargetID,
+			"target_name": names[sighting.TargetID],
+			"cat_id":      sighting.CatID,
+			"description": sighting.Description,
+			"observed_at": sighting.ObservedAt,
+		})
+	}
+
+	return collection, nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/google/uuid"
+	"time"
+)
+
+// SetTargetState moves the target along its transition table, once every target of the mission
+// reaches a terminal state the mission is completed on its own
+func (s *Service) SetTargetState(ctx context.Context, missionID, targetID uuid.UUID, state string) (*target.Target, error) {
+	const op = "service.SetTargetState"
+
+	mis, tar, err := s.missionTarget(ctx, missionID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = mis.CheckEditable(); err != nil {
+		return nil, err
+	}
+
+	fromState := tar.State
+	if err = tar.Transition(state, time.Now()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.tr.UpdateTargetState(ctx, tar, fromState); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	eventType := event.TargetStateChanged
+	if state == target.StateCompleted {
+		eventType = event.TargetCompleted
+	}
+
+	s.record(ctx, missionID, eventType, map[string]interface{}{
+		"target_id": targetID,
+		"from":      fromState,
+		"to":        state,
+	})
+
+	if target.IsTerminal(state) {
+		s.completeSettledMission(ctx, missionID)
+	}
+
+	return s.tr.GetMissionTarget(ctx, missionID, targetID)
+}
+
+// completeSettledMission the target change is already stored, so a mission that can't be completed
+// yet (open targets, pending prerequisites) is left as it is; it is checked again once its last
+// prerequisite completes
+func (s *Service) completeSettledMission(ctx context.Context, missionID uuid.UUID) {
+	const op = "service.completeSettledMission"
+
+	mis, err := s.mr.GetMissionByID(ctx, missionID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err)
+		return
+	}
+
+	if mis.State != startedState {
+		return
+	}
+
+	targets, err := s.tr.GetTargetsByMissionID(ctx, missionID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err)
+		return
+	}
+
+	for _, tar := range targets {
+		if !target.IsTerminal(tar.State) {
+			return
+		}
+	}
+
+	if err = s.checkPrerequisites(ctx, missionID); err != nil {
+		if !errors.Is(err, utils.ErrMissionBlocked) && !errors.Is(err, utils.ErrPrerequisiteFailed) {
+			logger.GetLoggerFromCtx(ctx).Error(op, err)
+		}
+		return
+	}
+
+	if err = s.mr.SetMissionCompleted(ctx, missionID); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err)
+		return
+	}
+
+	s.record(ctx, missionID, event.MissionCompleted, map[string]interface{}{"auto": true})
+
+	s.completeDependents(ctx, missionID)
+}
+
+// completeDependents the completed mission may have been the last open prerequisite of missions
+// whose targets are all settled already
+func (s *Service) completeDependents(ctx context.Context, missionID uuid.UUID) {
+	const op = "service.completeDependents"
+
+	dependents, err := s.mr.GetDependents(ctx, missionID)
+	if err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(op, err)
+		return
+	}
+
+	for _, dependent := range dependents {
+		s.completeSettledMission(ctx, dependent.ID)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// GetMissionTimeline pages through the mission events in chronological order
+func (s *Service) GetMissionTimeline(ctx context.Context, missionID uuid.UUID, cursor *mission.Cursor, limit int) (*event.Page, error) {
+	const op = "service.GetMissionTimeline"
+
+	switch {
+	case limit == 0:
+		limit = mission.DefaultPageSize
+	case limit < 0 || limit > mission.MaxPageSize:
+		return nil, utils.ErrInvalidQuery
+	}
+
+	if _, err := s.mr.GetMissionByID(ctx, missionID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page, err := s.evr.GetMissionEvents(ctx, missionID, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return page, nil
+}
+
+// record is best effort: the change it describes has already happened, so a failure is only logged
+func (s *Service) record(ctx context.Context, missionID uuid.UUID, eventType string, payload map[string]interface{}) {
+	const op = "service.record"
+
+	ev := event.NewEntity(missionID, eventType, event.ActorFromCtx(ctx), payload)
+	if err := s.evr.AddEvent(ctx, ev); err != nil {
+		logger.GetLoggerFromCtx(ctx).Error(fmt.Sprintf("%s: failed to record %s event", op, eventType), err)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/google/uuid"
+	"time"
+)
+
+func (s *Service) ListTrash(ctx context.Context) ([]*FullMission, error) {
+	const op = "service.ListTrash"
+
+	missions, err := s.mr.GetTrashedMissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	fullMissions, err := s.getFullMissions(ctx, &mission.Page{Missions: missions})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return fullMissions, nil
+}
+
+func (s *Service) RestoreMission(ctx context.Context, id uuid.UUID) (*FullMission, error) {
+	const op = "service.RestoreMission"
+
+	if err := s.mr.RestoreMission(ctx, id); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.record(ctx, id, event.MissionRestored, nil)
+
+	return s.GetMission(ctx, id)
+}
+
+// PurgeTrash hard-deletes the missions whose retention period is over and returns how many were purged
+func (s *Service) PurgeTrash(ctx context.Context) (int64, error) {
+	const op = "service.PurgeTrash"
+
+	purged, err := s.mr.PurgeTrash(ctx, time.Now().Add(-s.cfg.Trash.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/dossier"
+)
+
+// CreateDossierReq country accepts the same forms as on targets and is stored as an alpha-2 code
+type CreateDossierReq struct {
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+type LinkDossierTargetReq struct {
+	TargetID string `json:"target_id"`
+}
+
+func MapDossierReqToSvc(req CreateDossierReq) dossier.CreateDossierSvc {
+	return dossier.CreateDossierSvc{
+		Name:        req.Name,
+		Country:     req.Country,
+		Aliases:     req.Aliases,
+		Description: req.Description,
+	}
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+)
+
+type TimelineQuery struct {
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+// MapTimelineCursor an empty cursor starts from the first event of the mission
+func MapTimelineCursor(query TimelineQuery) (*mission.Cursor, error) {
+	if query.Cursor == "" {
+		return nil, nil
+	}
+
+	cursor, err := mission.DecodeCursor(query.Cursor)
+	if err != nil {
+		return nil, utils.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
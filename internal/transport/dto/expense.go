@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+const reportMonthLayout = "2006-01"
+
+// CreateExpenseReq is accepted both as JSON and as multipart form, the latter may carry a receipt file
+type CreateExpenseReq struct {
+	CatID       string `json:"cat_id,omitempty" form:"cat_id"`
+	AmountCents int64  `json:"amount_cents" form:"amount_cents"`
+	Currency    string `json:"currency,omitempty" form:"currency"`
+	Category    string `json:"category" form:"category"`
+	Description string `json:"description" form:"description"`
+}
+
+type UpdateExpenseStatusReq struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
+
+// ReportPeriodQuery both months are inclusive and formatted as YYYY-MM
+type ReportPeriodQuery struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+}
+
+func MapExpenseReqToSvc(req CreateExpenseReq) (service.CreateExpenseSvc, error) {
+	expenseSvc := service.CreateExpenseSvc{
+		AmountCents: req.AmountCents,
+		Currency:    req.Currency,
+		Category:    req.Category,
+		Description: req.Description,
+	}
+
+	if req.CatID != "" {
+		catID, err := uuid.Parse(req.CatID)
+		if err != nil {
+			return service.CreateExpenseSvc{}, utils.ErrInvalidID
+		}
+		expenseSvc.CatID = &catID
+	}
+
+	return expenseSvc, nil
+}
+
+// MapReportPeriod converts inclusive months into the [from, to) time range
+func MapReportPeriod(query ReportPeriodQuery) (time.Time, time.Time, error) {
+	from, err := time.Parse(reportMonthLayout, query.From)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.ErrInvalidPeriod
+	}
+
+	to, err := time.Parse(reportMonthLayout, query.To)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.ErrInvalidPeriod
+	}
+
+	return from, to.AddDate(0, 1, 0), nil
+}
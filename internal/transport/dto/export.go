@@ -0,0 +1,7 @@
+package dto
+
+// ExportQuery Format is one of md, json or csv, Template picks a custom Markdown template by name
+type ExportQuery struct {
+	Format   string `form:"format,default=md"`
+	Template string `form:"template"`
+}
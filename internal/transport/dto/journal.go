@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"time"
+)
+
+// CreateJournalEntryReq confidence is one of low, medium (default) and high, observed_at defaults to now
+type CreateJournalEntryReq struct {
+	Body       string     `json:"body"`
+	Location   *string    `json:"location,omitempty"`
+	Confidence string     `json:"confidence,omitempty"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+}
+
+// UpdateJournalEntryReq omitted fields are left unchanged
+type UpdateJournalEntryReq struct {
+	Body       *string    `json:"body,omitempty"`
+	Location   *string    `json:"location,omitempty"`
+	Confidence *string    `json:"confidence,omitempty"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+}
+
+func MapJournalEntryReqToSvc(req CreateJournalEntryReq) target.CreateJournalEntrySvc {
+	return target.CreateJournalEntrySvc{
+		Body:       req.Body,
+		Location:   req.Location,
+		Confidence: req.Confidence,
+		ObservedAt: req.ObservedAt,
+	}
+}
+
+func MapJournalEntryUpdateToParams(req UpdateJournalEntryReq) target.UpdateJournalEntryParams {
+	return target.UpdateJournalEntryParams{
+		Body:       req.Body,
+		Location:   req.Location,
+		Confidence: req.Confidence,
+		ObservedAt: req.ObservedAt,
+	}
+}
@@ -1,13 +1,122 @@
 package dto
 
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
 type CreateMissionReq struct {
-	Targets []CreateTargetReq `json:"targets"`
+	Targets     []CreateTargetReq `json:"targets"`
+	Type        string            `json:"mission_type,omitempty"`
+	Deadline    *time.Time        `json:"deadline,omitempty"`
+	StartedAt   *time.Time        `json:"started_at,omitempty"`
+	BudgetCents *int64            `json:"budget_cents,omitempty"`
+}
+
+type PatchMissionReq struct {
+	Deadline    *time.Time `json:"deadline,omitempty"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	BudgetCents *int64     `json:"budget_cents,omitempty"`
 }
 
 type UpdateMissionReq struct {
 	Notes string `json:"notes"`
 }
 
+// ListMissionsQuery dates are expected in RFC 3339, "from" bounds are inclusive and "to" bounds are exclusive
+type ListMissionsQuery struct {
+	State       string     `form:"state"`
+	Approval    string     `form:"approval"`
+	CatID       string     `form:"cat_id"`
+	Unassigned  bool       `form:"unassigned"`
+	Overdue     bool       `form:"overdue"`
+	Country     string     `form:"country"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom *time.Time `form:"updated_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo   *time.Time `form:"updated_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Search      string     `form:"q"`
+	Sort        string     `form:"sort"`
+	Order       string     `form:"order"`
+	Cursor      string     `form:"cursor"`
+	Limit       int        `form:"limit"`
+}
+
+// ApprovalDecisionReq the approver is taken from the actor header
+type ApprovalDecisionReq struct {
+	Comment string `json:"comment"`
+}
+
+// AbortMissionReq Reason is one of the known abort reason codes, Debrief is free text
+type AbortMissionReq struct {
+	Reason  string `json:"reason"`
+	Debrief string `json:"debrief"`
+}
+
+type AddDependencyReq struct {
+	PrerequisiteID string `json:"prerequisite_id"`
+}
+
+type CloneMissionReq struct {
+	IncludeNotes bool `json:"include_notes"`
+}
+
 type AssignToMissionReq struct {
 	CatID string `json:"cat_id"`
 }
+
+func MapMissionToRaw(req CreateMissionReq) service.CreateMissionSvc {
+	return service.CreateMissionSvc{
+		Targets:     MapTargetsToRaw(req.Targets),
+		Type:        req.Type,
+		Deadline:    req.Deadline,
+		StartedAt:   req.StartedAt,
+		BudgetCents: req.BudgetCents,
+	}
+}
+
+func MapMissionQuery(query ListMissionsQuery) (mission.QuerySpec, error) {
+	spec := mission.QuerySpec{
+		State:          query.State,
+		ApprovalStatus: query.Approval,
+		UnassignedOnly: query.Unassigned,
+		OverdueOnly:    query.Overdue,
+		Country:        query.Country,
+		CreatedFrom:    query.CreatedFrom,
+		CreatedTo:      query.CreatedTo,
+		UpdatedFrom:    query.UpdatedFrom,
+		UpdatedTo:      query.UpdatedTo,
+		TargetName:     query.Search,
+		SortBy:         query.Sort,
+		Limit:          query.Limit,
+	}
+
+	if query.CatID != "" {
+		catID, err := uuid.Parse(query.CatID)
+		if err != nil {
+			return spec, utils.ErrInvalidID
+		}
+		spec.CatID = &catID
+	}
+
+	switch query.Order {
+	case "", "asc":
+	case "desc":
+		spec.SortDesc = true
+	default:
+		return spec, utils.ErrInvalidQuery
+	}
+
+	if query.Cursor != "" {
+		cursor, err := mission.DecodeCursor(query.Cursor)
+		if err != nil {
+			return spec, utils.ErrInvalidCursor
+		}
+		spec.Cursor = cursor
+	}
+
+	return spec, nil
+}
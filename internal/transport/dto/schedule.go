@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/schedule"
+	"time"
+)
+
+// CreateScheduleReq exactly one of Cron (5 fields, UTC) and RunAt is expected
+type CreateScheduleReq struct {
+	Name                 string              `json:"name"`
+	MissionType          string              `json:"mission_type"`
+	Targets              []TargetSkeletonReq `json:"targets"`
+	BudgetCents          *int64              `json:"budget_cents,omitempty"`
+	DeadlineAfterSeconds *int64              `json:"deadline_after_seconds,omitempty"`
+	Cron                 *string             `json:"cron,omitempty"`
+	RunAt                *time.Time          `json:"run_at,omitempty"`
+	AutoAssign           bool                `json:"auto_assign"`
+}
+
+func MapScheduleReqToSvc(req CreateScheduleReq) schedule.CreateScheduleSvc {
+	var deadlineAfter *time.Duration
+	if req.DeadlineAfterSeconds != nil {
+		duration := time.Duration(*req.DeadlineAfterSeconds) * time.Second
+		deadlineAfter = &duration
+	}
+
+	return schedule.CreateScheduleSvc{
+		Name:          req.Name,
+		MissionType:   req.MissionType,
+		Targets:       MapSkeletons(req.Targets),
+		BudgetCents:   req.BudgetCents,
+		DeadlineAfter: deadlineAfter,
+		Cron:          req.Cron,
+		RunAt:         req.RunAt,
+		AutoAssign:    req.AutoAssign,
+	}
+}
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/google/uuid"
+	"time"
+)
+
+// CreateSightingReq cat_id defaults to the cat assigned to the mission, observed_at to now
+type CreateSightingReq struct {
+	CatID       string     `json:"cat_id,omitempty"`
+	Latitude    *float64   `json:"latitude"`
+	Longitude   *float64   `json:"longitude"`
+	Description string     `json:"description"`
+	ObservedAt  *time.Time `json:"observed_at,omitempty"`
+}
+
+func MapSightingReqToSvc(req CreateSightingReq) (target.CreateSightingSvc, error) {
+	if req.Latitude == nil || req.Longitude == nil {
+		return target.CreateSightingSvc{}, utils.ErrInvalidCoordinates
+	}
+
+	sightingSvc := target.CreateSightingSvc{
+		Latitude:    *req.Latitude,
+		Longitude:   *req.Longitude,
+		Description: req.Description,
+		ObservedAt:  req.ObservedAt,
+	}
+
+	if req.CatID != "" {
+		catID, err := uuid.Parse(req.CatID)
+		if err != nil {
+			return target.CreateSightingSvc{}, utils.ErrInvalidID
+		}
+		sightingSvc.CatID = &catID
+	}
+
+	return sightingSvc, nil
+}
@@ -1,6 +1,7 @@
 package dto
 
 import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
 )
 
@@ -23,3 +24,30 @@ func MapTargetsToRaw(reqTargets []CreateTargetReq) []service.CreateUpdateTargetS
 func MapTargetToRaw(reqTarget CreateTargetReq) service.CreateUpdateTargetSvc {
 	return service.CreateUpdateTargetSvc{Name: reqTarget.Name, Country: reqTarget.Country, Notes: reqTarget.Notes}
 }
+
+// NotesDiffQuery both revisions are required, From may be newer than To to see what a revert would change
+type NotesDiffQuery struct {
+	From int `form:"from"`
+	To   int `form:"to"`
+}
+
+type RevertNotesReq struct {
+	Revision int `json:"revision"`
+}
+
+type MoveTargetReq struct {
+	MissionID string `json:"mission_id"`
+}
+
+type UpdateTargetStateReq struct {
+	State string `json:"state"`
+}
+
+type SearchTargetsQuery struct {
+	Q     string `form:"q"`
+	Limit int    `form:"limit"`
+}
+
+func MapSearchQuery(query SearchTargetsQuery) target.SearchSpec {
+	return target.SearchSpec{Query: query.Q, Limit: query.Limit}
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/template"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"time"
+)
+
+type TargetSkeletonReq struct {
+	Name    string  `json:"name"`
+	Country string  `json:"country"`
+	Notes   *string `json:"notes,omitempty"`
+}
+
+type CreateTemplateReq struct {
+	Name         string              `json:"name"`
+	MissionType  string              `json:"mission_type"`
+	DefaultNotes string              `json:"default_notes"`
+	Targets      []TargetSkeletonReq `json:"targets"`
+}
+
+type UpdateTemplateReq struct {
+	Name         *string             `json:"name,omitempty"`
+	MissionType  *string             `json:"mission_type,omitempty"`
+	DefaultNotes *string             `json:"default_notes,omitempty"`
+	Targets      []TargetSkeletonReq `json:"targets,omitempty"`
+}
+
+type TargetOverrideReq struct {
+	Name    *string `json:"name,omitempty"`
+	Country *string `json:"country,omitempty"`
+	Notes   *string `json:"notes,omitempty"`
+}
+
+// CreateFromTemplateReq Overrides are keyed by the template target name
+type CreateFromTemplateReq struct {
+	Overrides map[string]TargetOverrideReq `json:"overrides,omitempty"`
+	Deadline  *time.Time                   `json:"deadline,omitempty"`
+	StartedAt *time.Time                   `json:"started_at,omitempty"`
+}
+
+func MapSkeletons(reqTargets []TargetSkeletonReq) []template.TargetSkeleton {
+	if reqTargets == nil {
+		return nil
+	}
+
+	skeletons := make([]template.TargetSkeleton, 0, len(reqTargets))
+	for _, reqTarget := range reqTargets {
+		skeletons = append(skeletons, template.TargetSkeleton{Name: reqTarget.Name, Country: reqTarget.Country, Notes: reqTarget.Notes})
+	}
+
+	return skeletons
+}
+
+func MapFromTemplateToRaw(req CreateFromTemplateReq) service.CreateFromTemplateSvc {
+	overrides := make(map[string]service.TargetOverrideSvc, len(req.Overrides))
+	for name, override := range req.Overrides {
+		overrides[name] = service.TargetOverrideSvc{Name: override.Name, Country: override.Country, Notes: override.Notes}
+	}
+
+	return service.CreateFromTemplateSvc{Overrides: overrides, Deadline: req.Deadline, StartedAt: req.StartedAt}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type approvalDecision func(ctx context.Context, id uuid.UUID, approver, comment string) (*service.FullMission, error)
+
+func (h *Handler) ApproveMission(c *gin.Context) {
+	h.decideApproval(c, "handler.ApproveMission", h.MisTargetService.ApproveMission)
+}
+
+func (h *Handler) RejectMission(c *gin.Context) {
+	h.decideApproval(c, "handler.RejectMission", h.MisTargetService.RejectMission)
+}
+
+// decideApproval the approver is whoever is named in the actor header, anonymous decisions are refused
+func (h *Handler) decideApproval(c *gin.Context, op string, decide approvalDecision) {
+	parsedID, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.ApprovalDecisionReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	decidedMission, err := decide(h.actorCtx(c), parsedID, c.GetHeader(actorHeader), req.Comment)
+	switch {
+	case errors.Is(err, utils.ErrApproverRequired):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrApproverRequired.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionCompleted.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionAborted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionAborted.Error()))
+		return
+	case errors.Is(err, utils.ErrApprovalClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrApprovalClosed.Error()))
+		return
+	case errors.Is(err, utils.ErrAlreadyDecided):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrAlreadyDecided.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, decidedMission)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+const prerequisiteIDParam = "prerequisite-id"
+
+func (h *Handler) AddMissionDependency(c *gin.Context) {
+	const op = "handler.AddMissionDependency"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.AddDependencyReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	prerequisiteID, err := uuid.Parse(req.PrerequisiteID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.AddMissionDependency(h.actorCtx(c), missionID, prerequisiteID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrDependencyCycle):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrDependencyCycle.Error()))
+		return
+	case errors.Is(err, utils.ErrPrerequisiteFailed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj("prerequisite mission is aborted"))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionCompleted.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionAborted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on adding mission dependency"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) RemoveMissionDependency(c *gin.Context) {
+	const op = "handler.RemoveMissionDependency"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	prerequisiteID, err := uuid.Parse(c.Param(prerequisiteIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.RemoveMissionDependency(h.actorCtx(c), missionID, prerequisiteID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrDependencyNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrDependencyNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionCompleted.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionAborted):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on removing mission dependency"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Handler) CreateDossier(c *gin.Context) {
+	const op = "handler.CreateDossier"
+
+	var req dto.CreateDossierReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	id, err := h.MisTargetService.CreateDossier(h.actorCtx(c), dto.MapDossierReqToSvc(req))
+	switch {
+	case errors.Is(err, utils.ErrInvalidDossier), errors.Is(err, utils.ErrUnknownCountry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListDossiers(c *gin.Context) {
+	const op = "handler.ListDossiers"
+
+	dossiers, err := h.MisTargetService.ListDossiers(h.Ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, dossiers)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) GetDossier(c *gin.Context) {
+	const op = "handler.GetDossier"
+
+	id, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	view, err := h.MisTargetService.GetDossier(h.actorCtx(c), id)
+	switch {
+	case errors.Is(err, utils.ErrDossierNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrDossierNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, view)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) LinkDossierTarget(c *gin.Context) {
+	const op = "handler.LinkDossierTarget"
+
+	dossierID, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.LinkDossierTargetReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	targetID, err := uuid.Parse(req.TargetID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.LinkDossierTarget(h.actorCtx(c), dossierID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrDossierNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrDossierNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound), errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on linking target to the dossier"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) UnlinkDossierTarget(c *gin.Context) {
+	const op = "handler.UnlinkDossierTarget"
+
+	dossierID, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	targetID, err := uuid.Parse(c.Param(targetIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.UnlinkDossierTarget(h.actorCtx(c), dossierID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotLinked):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotLinked.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on unlinking target from the dossier"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) SuggestDossiers(c *gin.Context) {
+	const op = "handler.SuggestDossiers"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	suggestions, err := h.MisTargetService.SuggestDossiers(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, suggestions)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
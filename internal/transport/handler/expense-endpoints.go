@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+const (
+	expenseIDParam   = "expense-id"
+	receiptFormField = "receipt"
+)
+
+func (h *Handler) AddMissionExpense(c *gin.Context) {
+	const op = "handler.AddMissionExpense"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.CreateExpenseReq
+	if err = c.ShouldBind(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	expenseSvc, err := dto.MapExpenseReqToSvc(req)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	if c.ContentType() == binding.MIMEMultipartPOSTForm {
+		fileHeader, err := c.FormFile(receiptFormField)
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to read receipt", op), err)
+			c.JSON(http.StatusBadRequest, BadRequestObj())
+			return
+		}
+
+		if fileHeader != nil {
+			file, err := fileHeader.Open()
+			if err != nil {
+				logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to open receipt", op), err)
+				c.JSON(http.StatusInternalServerError, InternalErrorObj())
+				return
+			}
+			defer file.Close()
+
+			expenseSvc.Receipt = &service.ReceiptSvc{Filename: fileHeader.Filename, Content: file}
+		}
+	}
+
+	id, err := h.MisTargetService.AddMissionExpense(h.actorCtx(c), missionID, expenseSvc)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidExpense):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidExpense.Error()))
+		return
+	case errors.Is(err, utils.ErrExpenseClaimant):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusForbidden, ErrorObj(utils.ErrExpenseClaimant.Error()))
+		return
+	case errors.Is(err, utils.ErrExpenseClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrExpenseClosed.Error()))
+		return
+	case errors.Is(err, utils.ErrReceiptTooLarge):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusRequestEntityTooLarge, ErrorObj(utils.ErrReceiptTooLarge.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) UpdateExpenseStatus(c *gin.Context) {
+	const op = "handler.UpdateExpenseStatus"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param(expenseIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.UpdateExpenseStatusReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on patch request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	updatedExpense, err := h.MisTargetService.UpdateExpenseStatus(h.actorCtx(c), missionID, expenseID, req.Status, req.Note)
+	switch {
+	case errors.Is(err, utils.ErrExpenseNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrExpenseNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrExpenseTransition):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(utils.ErrExpenseTransition.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, updatedExpense)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) GetExpenseReceipt(c *gin.Context) {
+	const op = "handler.GetExpenseReceipt"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	expenseID, err := uuid.Parse(c.Param(expenseIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	receiptPath, err := h.MisTargetService.GetExpenseReceipt(h.actorCtx(c), missionID, expenseID)
+	switch {
+	case errors.Is(err, utils.ErrExpenseNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrExpenseNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrReceiptNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrReceiptNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.File(receiptPath)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListMissionExpenses(c *gin.Context) {
+	const op = "handler.ListMissionExpenses"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	expenses, err := h.MisTargetService.ListMissionExpenses(h.actorCtx(c), missionID)
+	if err != nil {
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, expenses)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) GetMissionCost(c *gin.Context) {
+	const op = "handler.GetMissionCost"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	cost, err := h.MisTargetService.GetMissionCost(h.actorCtx(c), missionID)
+	if err != nil {
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, cost)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) GetCostReport(c *gin.Context) {
+	const op = "handler.GetCostReport"
+
+	var query dto.ReportPeriodQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+		return
+	}
+
+	from, to, err := dto.MapReportPeriod(query)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+		return
+	}
+
+	report, err := h.MisTargetService.CostReport(h.actorCtx(c), from, to)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidPeriod) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) GetPayrollReport(c *gin.Context) {
+	const op = "handler.GetPayrollReport"
+
+	var query dto.ReportPeriodQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+		return
+	}
+
+	from, to, err := dto.MapReportPeriod(query)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+		return
+	}
+
+	report, err := h.MisTargetService.PayrollReport(h.actorCtx(c), from, to)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidPeriod) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidPeriod.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"io"
+	"net/http"
+)
+
+type Exporter interface {
+	Export(w io.Writer, format, templateName string, debrief *service.Debrief) error
+	ContentType(format string) (string, error)
+}
+
+func (h *Handler) ExportMission(c *gin.Context) {
+	const op = "handler.ExportMission"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var query dto.ExportQuery
+	if err = c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidQuery.Error()))
+		return
+	}
+
+	contentType, err := h.Exporter.ContentType(query.Format)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnsupportedFormat.Error()))
+		return
+	}
+
+	debrief, err := h.MisTargetService.GetMissionDebrief(h.actorCtx(c), missionID)
+	if err != nil {
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	// rendered in memory first so a failing template still ends in a proper error response
+	var buf bytes.Buffer
+	if err = h.Exporter.Export(&buf, query.Format, query.Template, debrief); err != nil {
+		if errors.Is(err, utils.ErrUnknownExportTmpl) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownExportTmpl.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="mission-%s.%s"`, missionID, query.Format))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/server"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
 	"github.com/gin-gonic/gin"
@@ -12,22 +13,49 @@ import (
 type Handler struct {
 	CatService       CatService
 	MisTargetService MisTargetService
+	TemplateService  TemplateService
+	Exporter         Exporter
 	Router           *gin.Engine
 	Server           *http.Server
 	Ctx              context.Context
 }
 
 const (
-	catsPath    = "/cats"
-	missionPath = "/missions"
-	targetsPath = "/:id/targets"
+	catsPath      = "/cats"
+	missionPath   = "/missions"
+	targetsPath   = "/:id/targets"
+	templatesPath = "/mission-templates"
+	schedulesPath = "/mission-schedules"
+	dossiersPath  = "/dossiers"
+	searchPath    = "/search"
+
+	actorHeader    = "X-Actor"
+	anonymousActor = "anonymous"
 )
 
-func New(ctx context.Context, cfg server.Config, catService CatService, misTarService MisTargetService) *Handler {
+func New(ctx context.Context, cfg server.Config, catService CatService, misTarService MisTargetService, templateService TemplateService, exporter Exporter) *Handler {
 	router := gin.New()
 	srv := server.New(cfg)
 
-	return &Handler{Ctx: ctx, CatService: catService, MisTargetService: misTarService, Router: router, Server: srv}
+	return &Handler{
+		Ctx:              ctx,
+		CatService:       catService,
+		MisTargetService: misTarService,
+		TemplateService:  templateService,
+		Exporter:         exporter,
+		Router:           router,
+		Server:           srv,
+	}
+}
+
+// actorCtx attributes the changes made while serving the request to the caller named in the actor header
+func (h *Handler) actorCtx(c *gin.Context) context.Context {
+	actor := c.GetHeader(actorHeader)
+	if actor == "" {
+		actor = anonymousActor
+	}
+
+	return event.WithActor(h.Ctx, actor)
 }
 
 func (h *Handler) InitRoutes() {
@@ -46,16 +74,82 @@ func (h *Handler) InitRoutes() {
 	missionsGroup := h.Router.Group(missionPath)
 	{
 		missionsGroup.GET("", h.ListMissions)
+		missionsGroup.GET("/cost-report", h.GetCostReport)
+		missionsGroup.GET("/payroll", h.GetPayrollReport)
+		missionsGroup.GET("/trash", h.ListTrash)
 		missionsGroup.POST("", h.CreateMission)
+		missionsGroup.POST("/from-template/:template-id", h.CreateMissionFromTemplate)
 		missionsGroup.GET("/:id", h.GetMission)
 		missionsGroup.DELETE("/:id", h.DeleteMission)
 		missionsGroup.PUT("/:id", h.UpdateMissionState)
+		missionsGroup.PATCH("/:id", h.UpdateMission)
 		missionsGroup.PUT("/:id/assign", h.AssignMission)
+		missionsGroup.POST("/:id/auto-assign", h.AutoAssignMission)
+		missionsGroup.POST("/:id/clone", h.CloneMission)
+		missionsGroup.POST("/:id/abort", h.AbortMission)
+		missionsGroup.POST("/:id/restore", h.RestoreMission)
+		missionsGroup.POST("/:id/approve", h.ApproveMission)
+		missionsGroup.POST("/:id/reject", h.RejectMission)
+		missionsGroup.GET("/:id/timeline", h.GetMissionTimeline)
+		missionsGroup.GET("/:id/export", h.ExportMission)
+		missionsGroup.GET("/:id/sightings", h.ExportMissionSightings)
+		missionsGroup.POST("/:id/dependencies", h.AddMissionDependency)
+		missionsGroup.DELETE("/:id/dependencies/:prerequisite-id", h.RemoveMissionDependency)
+		missionsGroup.GET("/:id/cost", h.GetMissionCost)
+		missionsGroup.GET("/:id/expenses", h.ListMissionExpenses)
+		missionsGroup.POST("/:id/expenses", h.AddMissionExpense)
+		missionsGroup.PATCH("/:id/expenses/:expense-id", h.UpdateExpenseStatus)
+		missionsGroup.GET("/:id/expenses/:expense-id/receipt", h.GetExpenseReceipt)
 
 		targetsGroup := missionsGroup.Group(targetsPath)
 		targetsGroup.PUT("/:target-id", h.UpdateMissionTarget)
 		targetsGroup.POST("/", h.AddMissionTarget)
 		targetsGroup.DELETE("/:target-id", h.DeleteMissionTarget)
+		targetsGroup.POST("/:target-id/complete", h.CompleteMissionTarget)
+		targetsGroup.GET("/:target-id/notes/history", h.GetNotesHistory)
+		targetsGroup.GET("/:target-id/notes/diff", h.DiffNotesRevisions)
+		targetsGroup.POST("/:target-id/notes/revert", h.RevertTargetNotes)
+		targetsGroup.POST("/:target-id/notes/summary", h.SummarizeTargetNotes)
+		targetsGroup.GET("/:target-id/entries", h.ListJournalEntries)
+		targetsGroup.POST("/:target-id/entries", h.AddJournalEntry)
+		targetsGroup.PATCH("/:target-id/entries/:entry-id", h.UpdateJournalEntry)
+		targetsGroup.GET("/:target-id/sightings", h.ListTargetSightings)
+		targetsGroup.POST("/:target-id/sightings", h.AddTargetSighting)
+		targetsGroup.GET("/:target-id/dossier-suggestions", h.SuggestDossiers)
+		targetsGroup.POST("/:target-id/move", h.MoveMissionTarget)
+		targetsGroup.PATCH("/:target-id/state", h.UpdateTargetState)
+	}
+
+	templatesGroup := h.Router.Group(templatesPath)
+	{
+		templatesGroup.GET("", h.ListTemplates)
+		templatesGroup.GET("/:id", h.GetTemplate)
+		templatesGroup.POST("", h.CreateTemplate)
+		templatesGroup.PUT("/:id", h.UpdateTemplate)
+		templatesGroup.DELETE("/:id", h.DeleteTemplate)
+	}
+
+	schedulesGroup := h.Router.Group(schedulesPath)
+	{
+		schedulesGroup.GET("", h.ListSchedules)
+		schedulesGroup.POST("", h.CreateSchedule)
+		schedulesGroup.POST("/:id/pause", h.PauseSchedule)
+		schedulesGroup.POST("/:id/resume", h.ResumeSchedule)
+		schedulesGroup.DELETE("/:id", h.DeleteSchedule)
+	}
+
+	dossiersGroup := h.Router.Group(dossiersPath)
+	{
+		dossiersGroup.GET("", h.ListDossiers)
+		dossiersGroup.POST("", h.CreateDossier)
+		dossiersGroup.GET("/:id", h.GetDossier)
+		dossiersGroup.POST("/:id/targets", h.LinkDossierTarget)
+		dossiersGroup.DELETE("/:id/targets/:target-id", h.UnlinkDossierTarget)
+	}
+
+	searchGroup := h.Router.Group(searchPath)
+	{
+		searchGroup.GET("/targets", h.SearchTargets)
 	}
 }
 
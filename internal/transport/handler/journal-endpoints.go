@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+const entryIDParam = "entry-id"
+
+func (h *Handler) AddJournalEntry(c *gin.Context) {
+	const op = "handler.AddJournalEntry"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateJournalEntryReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	id, err := h.MisTargetService.AddJournalEntry(h.actorCtx(c), missionID, targetID, dto.MapJournalEntryReqToSvc(req))
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidEntry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidEntry.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListJournalEntries(c *gin.Context) {
+	const op = "handler.ListJournalEntries"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	entries, err := h.MisTargetService.ListJournalEntries(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, entries)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) UpdateJournalEntry(c *gin.Context) {
+	const op = "handler.UpdateJournalEntry"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	entryID, err := uuid.Parse(c.Param(entryIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.UpdateJournalEntryReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on patch request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	entry, err := h.MisTargetService.UpdateJournalEntry(h.actorCtx(c), missionID, targetID, entryID, dto.MapJournalEntryUpdateToParams(req))
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrEntryNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrEntryNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidEntry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidEntry.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, entry)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) SummarizeTargetNotes(c *gin.Context) {
+	const op = "handler.SummarizeTargetNotes"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	revision, err := h.MisTargetService.SummarizeTargetNotes(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, revision)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -4,32 +4,86 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/dossier"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/event"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/expense"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/geo"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/mission"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/schedule"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/target"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/service"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
 	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 const (
-	missionIDParam = "id"
-	targetIDParam  = "target-id"
+	missionIDParam   = "id"
+	targetIDParam    = "target-id"
+	dryRunQuery      = "dry_run"
+	nextCursorHeader = "X-Next-Cursor"
 )
 
 type MisTargetService interface {
-	CreateMission(ctx context.Context, rawTargets []service.CreateUpdateTargetSvc) (uuid.UUID, error)
+	CreateMission(ctx context.Context, req service.CreateMissionSvc) (uuid.UUID, error)
+	CreateMissionFromTemplate(ctx context.Context, templateID uuid.UUID, req service.CreateFromTemplateSvc) (uuid.UUID, error)
+	CloneMission(ctx context.Context, id uuid.UUID, withNotes bool) (uuid.UUID, error)
 	DeleteMission(ctx context.Context, id uuid.UUID) error
+	UpdateMission(ctx context.Context, params mission.UpdateMissionParams) (*service.FullMission, error)
 	UpdateMissionState(ctx context.Context, id uuid.UUID) error
 	SetMissionTargetState(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID) error
 	UpdateMissionTargetNotes(ctx context.Context, missionID uuid.UUID, targetID uuid.UUID, notes string) error
-	DeleteTargetFromMission(ctx context.Context, id uuid.UUID) error
-	AddTargetToMission(ctx context.Context, missionID uuid.UUID, tarReq service.CreateUpdateTargetSvc) error
+	DeleteTargetFromMission(ctx context.Context, missionID, id uuid.UUID) error
+	AddTargetToMission(ctx context.Context, missionID uuid.UUID, tarReq service.CreateUpdateTargetSvc) (uuid.UUID, error)
 	AssignCatToMission(ctx context.Context, missionID uuid.UUID, catID uuid.UUID) error
-	ListMissions(ctx context.Context) ([]*service.FullMission, error)
+	AutoAssignCat(ctx context.Context, missionID uuid.UUID, dryRun bool) (*service.AutoAssignResult, error)
+	ListMissions(ctx context.Context, spec mission.QuerySpec) (*service.MissionPage, error)
 	GetMission(ctx context.Context, id uuid.UUID) (*service.FullMission, error)
+	AbortMission(ctx context.Context, id uuid.UUID, reason, debrief string) (*service.FullMission, error)
+	GetMissionDebrief(ctx context.Context, id uuid.UUID) (*service.Debrief, error)
+	GetMissionTimeline(ctx context.Context, missionID uuid.UUID, cursor *mission.Cursor, limit int) (*event.Page, error)
+	AddMissionDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error
+	RemoveMissionDependency(ctx context.Context, missionID, prerequisiteID uuid.UUID) error
+	GetMissionCost(ctx context.Context, id uuid.UUID) (*service.MissionCost, error)
+	CostReport(ctx context.Context, from, to time.Time) ([]*service.MonthlyCost, error)
+	AddMissionExpense(ctx context.Context, missionID uuid.UUID, req service.CreateExpenseSvc) (uuid.UUID, error)
+	ListMissionExpenses(ctx context.Context, missionID uuid.UUID) ([]*expense.Expense, error)
+	UpdateExpenseStatus(ctx context.Context, missionID, expenseID uuid.UUID, status, note string) (*expense.Expense, error)
+	GetExpenseReceipt(ctx context.Context, missionID, expenseID uuid.UUID) (string, error)
+	PayrollReport(ctx context.Context, from, to time.Time) ([]*service.CatPayroll, error)
+	GetNotesHistory(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.NotesRevision, error)
+	DiffNotesRevisions(ctx context.Context, missionID, targetID uuid.UUID, from, to int) (*target.NotesDiff, error)
+	RevertTargetNotes(ctx context.Context, missionID, targetID uuid.UUID, revision int) (*target.NotesRevision, error)
+	SummarizeTargetNotes(ctx context.Context, missionID, targetID uuid.UUID) (*target.NotesRevision, error)
+	AddJournalEntry(ctx context.Context, missionID, targetID uuid.UUID, req target.CreateJournalEntrySvc) (uuid.UUID, error)
+	ListJournalEntries(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.JournalEntry, error)
+	UpdateJournalEntry(ctx context.Context, missionID, targetID, entryID uuid.UUID, params target.UpdateJournalEntryParams) (*target.JournalEntry, error)
+	AddTargetSighting(ctx context.Context, missionID, targetID uuid.UUID, req target.CreateSightingSvc) (uuid.UUID, error)
+	ListTargetSightings(ctx context.Context, missionID, targetID uuid.UUID) ([]*target.Sighting, error)
+	MissionSightingsGeoJSON(ctx context.Context, missionID uuid.UUID) (*geo.FeatureCollection, error)
+	CreateDossier(ctx context.Context, req dossier.CreateDossierSvc) (uuid.UUID, error)
+	ListDossiers(ctx context.Context) ([]*dossier.Dossier, error)
+	GetDossier(ctx context.Context, id uuid.UUID) (*service.DossierView, error)
+	LinkDossierTarget(ctx context.Context, dossierID, targetID uuid.UUID) error
+	UnlinkDossierTarget(ctx context.Context, dossierID, targetID uuid.UUID) error
+	SuggestDossiers(ctx context.Context, missionID, targetID uuid.UUID) ([]*dossier.Suggestion, error)
+	MoveTarget(ctx context.Context, missionID, targetID, destMissionID uuid.UUID) error
+	SetTargetState(ctx context.Context, missionID, targetID uuid.UUID, state string) (*target.Target, error)
+	SearchTargets(ctx context.Context, spec target.SearchSpec) ([]*target.SearchResult, error)
+	ListTrash(ctx context.Context) ([]*service.FullMission, error)
+	RestoreMission(ctx context.Context, id uuid.UUID) (*service.FullMission, error)
+	ApproveMission(ctx context.Context, id uuid.UUID, approver, comment string) (*service.FullMission, error)
+	RejectMission(ctx context.Context, id uuid.UUID, approver, comment string) (*service.FullMission, error)
+	CreateSchedule(ctx context.Context, req schedule.CreateScheduleSvc) (uuid.UUID, error)
+	ListSchedules(ctx context.Context) ([]*schedule.Schedule, error)
+	SetSchedulePaused(ctx context.Context, id uuid.UUID, paused bool) (*schedule.Schedule, error)
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
 }
 
 func (h *Handler) CreateMission(c *gin.Context) {
@@ -48,16 +102,28 @@ func (h *Handler) CreateMission(c *gin.Context) {
 		return
 	}
 
-	id, err := h.MisTargetService.CreateMission(h.Ctx, dto.MapTargetsToRaw(req.Targets))
+	id, err := h.MisTargetService.CreateMission(h.actorCtx(c), dto.MapMissionToRaw(req))
 	switch {
 	case errors.Is(err, utils.ErrNoTargets):
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusBadRequest, ErrorObj("no targets were specified"))
 		return
+	case errors.Is(err, utils.ErrInvalidDeadline):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidDeadline.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidBudget):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidBudget.Error()))
+		return
 	case errors.Is(err, utils.ErrConflictingData):
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusBadRequest, ErrorObj("duplicate of unique data"))
 		return
+	case errors.Is(err, utils.ErrUnknownCountry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownCountry.Error()))
+		return
 	case errors.Is(err, utils.ErrValidatingTargets):
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusBadRequest, ErrorObj("failed to pass validation on target object"))
@@ -73,6 +139,42 @@ func (h *Handler) CreateMission(c *gin.Context) {
 	}
 }
 
+func (h *Handler) CloneMission(c *gin.Context) {
+	const op = "handler.CloneMission"
+
+	id := c.Param(idParam)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	// the body is optional, notes are not copied by default
+	var req dto.CloneMissionReq
+	if err = c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	cloneID, err := h.MisTargetService.CloneMission(h.actorCtx(c), parsedID, req.IncludeNotes)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": cloneID})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
 func (h *Handler) DeleteMission(c *gin.Context) {
 	const op = "handler.DeleteMission"
 
@@ -84,7 +186,7 @@ func (h *Handler) DeleteMission(c *gin.Context) {
 		return
 	}
 
-	if err = h.MisTargetService.DeleteMission(h.Ctx, parsedID); err != nil {
+	if err = h.MisTargetService.DeleteMission(h.actorCtx(c), parsedID); err != nil {
 		switch {
 		case errors.Is(err, utils.ErrMissionNotFound):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
@@ -120,13 +222,25 @@ func (h *Handler) UpdateMissionState(c *gin.Context) {
 		return
 	}
 
-	err = h.MisTargetService.UpdateMissionState(h.Ctx, parsedID)
+	err = h.MisTargetService.UpdateMissionState(h.actorCtx(c), parsedID)
 	if err != nil {
 		switch {
 		case errors.Is(err, utils.ErrMissionNotFound):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj("invalid breed"))
 			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionBlocked):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionBlocked.Error()))
+			return
+		case errors.Is(err, utils.ErrPrerequisiteFailed):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrPrerequisiteFailed.Error()))
+			return
 		default:
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusInternalServerError, InternalErrorObj())
@@ -138,6 +252,110 @@ func (h *Handler) UpdateMissionState(c *gin.Context) {
 	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
 }
 
+func (h *Handler) AbortMission(c *gin.Context) {
+	const op = "handler.AbortMission"
+
+	parsedID, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.AbortMissionReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	abortedMission, err := h.MisTargetService.AbortMission(h.actorCtx(c), parsedID, req.Reason, req.Debrief)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidAbortReason):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidAbortReason.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionCompleted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionCompleted.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionNotFound):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		default:
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusInternalServerError, InternalErrorObj())
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, abortedMission)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) UpdateMission(c *gin.Context) {
+	const op = "handler.UpdateMission"
+
+	id := c.Param(idParam)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.PatchMissionReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on patch request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	updatedMission, err := h.MisTargetService.UpdateMission(h.actorCtx(c), mission.UpdateMissionParams{
+		ID:          parsedID,
+		Deadline:    req.Deadline,
+		StartedAt:   req.StartedAt,
+		BudgetCents: req.BudgetCents,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrInvalidDeadline):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidDeadline.Error()))
+			return
+		case errors.Is(err, utils.ErrInvalidBudget):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidBudget.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionCompleted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("mission is already completed"))
+			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionNotFound):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		default:
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusInternalServerError, InternalErrorObj())
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, updatedMission)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
 func (h *Handler) UpdateMissionTarget(c *gin.Context) {
 	const op = "handler.UpdateMissionTarget"
 
@@ -164,21 +382,37 @@ func (h *Handler) UpdateMissionTarget(c *gin.Context) {
 		return
 	}
 
-	err = h.MisTargetService.UpdateMissionTargetNotes(h.Ctx, parsedMissionID, parsedTargetID, req.Notes)
+	err = h.MisTargetService.UpdateMissionTargetNotes(h.actorCtx(c), parsedMissionID, parsedTargetID, req.Notes)
 	if err != nil {
 		switch true {
 		case errors.Is(err, utils.ErrMissionCompleted):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj("mission is already completed"))
 			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
 		case errors.Is(err, utils.ErrTargetCompleted):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj("target is already completed"))
 			return
+		case errors.Is(err, utils.ErrTargetAbandoned):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetAbandoned.Error()))
+			return
+		case errors.Is(err, utils.ErrTargetClosed):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetClosed.Error()))
+			return
 		case errors.Is(err, utils.ErrMissionNotFound):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
 			return
+		case errors.Is(err, utils.ErrTargetNotFound):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+			return
 		default:
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusInternalServerError, InternalErrorObj())
@@ -193,19 +427,16 @@ func (h *Handler) UpdateMissionTarget(c *gin.Context) {
 func (h *Handler) DeleteMissionTarget(c *gin.Context) {
 	const op = "handler.DeleteMissionTarget"
 
-	targetID := c.Param(targetIDParam)
-	parsedTargetID, err := uuid.Parse(targetID)
-	if err != nil {
-		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
-		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
 		return
 	}
 
-	err = h.MisTargetService.DeleteTargetFromMission(h.Ctx, parsedTargetID)
+	err := h.MisTargetService.DeleteTargetFromMission(h.actorCtx(c), missionID, targetID)
 	if err != nil {
 		if errors.Is(err, utils.ErrTargetNotFound) {
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
-			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetNotFound.Error()))
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
 			return
 		}
 
@@ -215,6 +446,24 @@ func (h *Handler) DeleteMissionTarget(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, utils.ErrTargetAbandoned) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetAbandoned.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrTargetClosed) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetClosed.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		}
+
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusInternalServerError, InternalErrorObj())
 		return
@@ -242,13 +491,17 @@ func (h *Handler) AddMissionTarget(c *gin.Context) {
 		return
 	}
 
-	err = h.MisTargetService.AddTargetToMission(h.Ctx, parsedMissionID, dto.MapTargetToRaw(req))
+	targetID, err := h.MisTargetService.AddTargetToMission(h.actorCtx(c), parsedMissionID, dto.MapTargetToRaw(req))
 	if err != nil {
 		switch true {
 		case errors.Is(err, utils.ErrMissionCompleted):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionCompleted.Error()))
 			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
 		case errors.Is(err, utils.ErrMissionNotFound):
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
@@ -261,6 +514,10 @@ func (h *Handler) AddMissionTarget(c *gin.Context) {
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTargetOverflow.Error()))
 			return
+		case errors.Is(err, utils.ErrUnknownCountry):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownCountry.Error()))
+			return
 		default:
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 			c.JSON(http.StatusInternalServerError, InternalErrorObj())
@@ -268,7 +525,18 @@ func (h *Handler) AddMissionTarget(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, map[string]interface{}{"status": "success on adding target to the mission"})
+	// the target is already added, failing suggestions only leave the list empty
+	suggestions, err := h.MisTargetService.SuggestDossiers(h.actorCtx(c), parsedMissionID, targetID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to suggest dossiers", op), err)
+		suggestions = make([]*dossier.Suggestion, 0)
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{
+		"status":              "success on adding target to the mission",
+		"obj_id":              targetID,
+		"dossier_suggestions": suggestions,
+	})
 	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
 }
 
@@ -297,7 +565,7 @@ func (h *Handler) AssignMission(c *gin.Context) {
 		return
 	}
 
-	err = h.MisTargetService.AssignCatToMission(h.Ctx, missionID, catID)
+	err = h.MisTargetService.AssignCatToMission(h.actorCtx(c), missionID, catID)
 	if err != nil {
 		if errors.Is(err, utils.ErrMissionNotFound) {
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
@@ -305,6 +573,36 @@ func (h *Handler) AssignMission(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, utils.ErrCatUnavailable) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrCatUnavailable.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrMissionAborted) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrMissionBlocked) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionBlocked.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrPrerequisiteFailed) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrPrerequisiteFailed.Error()))
+			return
+		}
+
+		if errors.Is(err, utils.ErrMissionNotApproved) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionNotApproved.Error()))
+			return
+		}
+
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusInternalServerError, InternalErrorObj())
 		return
@@ -314,17 +612,112 @@ func (h *Handler) AssignMission(c *gin.Context) {
 	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
 }
 
+func (h *Handler) AutoAssignMission(c *gin.Context) {
+	const op = "handler.AutoAssignMission"
+
+	rawMissionID := c.Param(idParam)
+	missionID, err := uuid.Parse(rawMissionID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	dryRun := false
+	if rawDryRun := c.Query(dryRunQuery); rawDryRun != "" {
+		if dryRun, err = strconv.ParseBool(rawDryRun); err != nil {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("invalid dry_run value"))
+			return
+		}
+	}
+
+	result, err := h.MisTargetService.AutoAssignCat(h.actorCtx(c), missionID, dryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrMissionNotFound):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionCompleted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("mission is already completed"))
+			return
+		case errors.Is(err, utils.ErrMissionAborted):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrMissionAborted.Error()))
+			return
+		case errors.Is(err, utils.ErrCatAssigned):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("mission already has a cat assigned"))
+			return
+		case errors.Is(err, utils.ErrNoAvailableCats):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrNoAvailableCats.Error()))
+			return
+		case errors.Is(err, utils.ErrCatUnavailable):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrCatUnavailable.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionBlocked):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionBlocked.Error()))
+			return
+		case errors.Is(err, utils.ErrPrerequisiteFailed):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrPrerequisiteFailed.Error()))
+			return
+		case errors.Is(err, utils.ErrMissionNotApproved):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusConflict, ErrorObj(utils.ErrMissionNotApproved.Error()))
+			return
+		default:
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusInternalServerError, InternalErrorObj())
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
 func (h *Handler) ListMissions(c *gin.Context) {
 	const op = "handler.ListMissions"
 
-	missions, err := h.MisTargetService.ListMissions(h.Ctx)
+	var query dto.ListMissionsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidQuery.Error()))
+		return
+	}
+
+	spec, err := dto.MapMissionQuery(query)
 	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(err.Error()))
+		return
+	}
+
+	page, err := h.MisTargetService.ListMissions(h.actorCtx(c), spec)
+	if err != nil {
+		if errors.Is(err, utils.ErrInvalidQuery) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidQuery.Error()))
+			return
+		}
+
 		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
 		c.JSON(http.StatusInternalServerError, ErrorObj("internal server error"))
 		return
 	}
 
-	c.JSON(http.StatusOK, missions)
+	// the body stays a plain list, the cursor of the next page travels in the header
+	if page.NextCursor != "" {
+		c.Header(nextCursorHeader, page.NextCursor)
+	}
+
+	c.JSON(http.StatusOK, page.Missions)
 	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
 }
 
@@ -339,7 +732,7 @@ func (h *Handler) GetMission(c *gin.Context) {
 		return
 	}
 
-	fetchedMission, err := h.MisTargetService.GetMission(h.Ctx, parsedID)
+	fetchedMission, err := h.MisTargetService.GetMission(h.actorCtx(c), parsedID)
 	if err != nil {
 		if errors.Is(err, utils.ErrMissionNotFound) {
 			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
@@ -355,3 +748,138 @@ func (h *Handler) GetMission(c *gin.Context) {
 	c.JSON(http.StatusOK, fetchedMission)
 	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
 }
+
+func (h *Handler) MoveMissionTarget(c *gin.Context) {
+	const op = "handler.MoveMissionTarget"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var req dto.MoveTargetReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	destMissionID, err := uuid.Parse(req.MissionID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.MoveTarget(h.actorCtx(c), missionID, targetID, destMissionID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrSameMission):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrSameMission.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed), errors.Is(err, utils.ErrTargetOverflow),
+		errors.Is(err, utils.ErrLastTarget):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on moving target to another mission"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) UpdateTargetState(c *gin.Context) {
+	const op = "handler.UpdateTargetState"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateTargetStateReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on patch request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	updatedTarget, err := h.MisTargetService.SetTargetState(h.actorCtx(c), missionID, targetID, req.State)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrUnknownTargetState):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownTargetState.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed), errors.Is(err, utils.ErrTargetTransition):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, updatedTarget)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) CompleteMissionTarget(c *gin.Context) {
+	const op = "handler.CompleteMissionTarget"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	err := h.MisTargetService.SetMissionTargetState(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed), errors.Is(err, utils.ErrTargetTransition):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on mission's target completion"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// parseMissionTarget responds with bad request itself when either of the ids is malformed
+func (h *Handler) parseMissionTarget(c *gin.Context, op string) (uuid.UUID, uuid.UUID, bool) {
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	targetID, err := uuid.Parse(c.Param(targetIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return missionID, targetID, true
+}
+
+func (h *Handler) GetNotesHistory(c *gin.Context) {
+	const op = "handler.GetNotesHistory"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.MisTargetService.GetNotesHistory(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, revisions)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) DiffNotesRevisions(c *gin.Context) {
+	const op = "handler.DiffNotesRevisions"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var query dto.NotesDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidQuery.Error()))
+		return
+	}
+
+	diff, err := h.MisTargetService.DiffNotesRevisions(h.actorCtx(c), missionID, targetID, query.From, query.To)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidQuery):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidQuery.Error()))
+		return
+	case errors.Is(err, utils.ErrRevisionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrRevisionNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, diff)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) RevertTargetNotes(c *gin.Context) {
+	const op = "handler.RevertTargetNotes"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var req dto.RevertNotesReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	revision, err := h.MisTargetService.RevertTargetNotes(h.actorCtx(c), missionID, targetID, req.Revision)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrRevisionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrRevisionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, revision)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
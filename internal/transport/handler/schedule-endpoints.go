@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+func (h *Handler) CreateSchedule(c *gin.Context) {
+	const op = "handler.CreateSchedule"
+
+	var req dto.CreateScheduleReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	id, err := h.MisTargetService.CreateSchedule(h.actorCtx(c), dto.MapScheduleReqToSvc(req))
+	switch {
+	case errors.Is(err, utils.ErrValidatingSchedule):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("failed to pass validation on schedule object"))
+		return
+	case errors.Is(err, utils.ErrInvalidCron):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidCron.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidBudget):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidBudget.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidDeadline):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidDeadline.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListSchedules(c *gin.Context) {
+	const op = "handler.ListSchedules"
+
+	schedules, err := h.MisTargetService.ListSchedules(h.Ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) PauseSchedule(c *gin.Context) {
+	h.setSchedulePaused(c, "handler.PauseSchedule", true)
+}
+
+func (h *Handler) ResumeSchedule(c *gin.Context) {
+	h.setSchedulePaused(c, "handler.ResumeSchedule", false)
+}
+
+func (h *Handler) setSchedulePaused(c *gin.Context, op string, paused bool) {
+	id, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	sch, err := h.MisTargetService.SetSchedulePaused(h.actorCtx(c), id, paused)
+	switch {
+	case errors.Is(err, utils.ErrScheduleNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrScheduleNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, sch)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) DeleteSchedule(c *gin.Context) {
+	const op = "handler.DeleteSchedule"
+
+	id, err := uuid.Parse(c.Param(idParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	err = h.MisTargetService.DeleteSchedule(h.actorCtx(c), id)
+	switch {
+	case errors.Is(err, utils.ErrScheduleNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrScheduleNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, map[string]interface{}{"status": "success on schedule deletion operation"})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
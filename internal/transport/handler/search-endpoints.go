@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func (h *Handler) SearchTargets(c *gin.Context) {
+	const op = "handler.SearchTargets"
+
+	var query dto.SearchTargetsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map query parameters", op), err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidSearch.Error()))
+		return
+	}
+
+	results, err := h.MisTargetService.SearchTargets(h.actorCtx(c), dto.MapSearchQuery(query))
+	switch {
+	case errors.Is(err, utils.ErrInvalidSearch):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidSearch.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, results)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+const geoJSONContentType = "application/geo+json; charset=utf-8"
+
+func (h *Handler) AddTargetSighting(c *gin.Context) {
+	const op = "handler.AddTargetSighting"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateSightingReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	sightingSvc, err := dto.MapSightingReqToSvc(req)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(err.Error()))
+		return
+	}
+
+	id, err := h.MisTargetService.AddTargetSighting(h.actorCtx(c), missionID, targetID, sightingSvc)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrCatNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrCatNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidSighting), errors.Is(err, utils.ErrInvalidCoordinates),
+		errors.Is(err, utils.ErrOutsideCountry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(err.Error()))
+		return
+	case errors.Is(err, utils.ErrMissionCompleted), errors.Is(err, utils.ErrMissionAborted),
+		errors.Is(err, utils.ErrTargetCompleted), errors.Is(err, utils.ErrTargetAbandoned),
+		errors.Is(err, utils.ErrTargetClosed):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusConflict, ErrorObj(err.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListTargetSightings(c *gin.Context) {
+	const op = "handler.ListTargetSightings"
+
+	missionID, targetID, ok := h.parseMissionTarget(c, op)
+	if !ok {
+		return
+	}
+
+	sightings, err := h.MisTargetService.ListTargetSightings(h.actorCtx(c), missionID, targetID)
+	switch {
+	case errors.Is(err, utils.ErrMissionNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrTargetNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusNotFound, ErrorObj(utils.ErrTargetNotFound.Error()))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusOK, sightings)
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+// ExportMissionSightings responds with a GeoJSON feature collection of all sightings of the mission
+func (h *Handler) ExportMissionSightings(c *gin.Context) {
+	const op = "handler.ExportMissionSightings"
+
+	missionID, err := uuid.Parse(c.Param(missionIDParam))
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	collection, err := h.MisTargetService.MissionSightingsGeoJSON(h.actorCtx(c), missionID)
+	if err != nil {
+		if errors.Is(err, utils.ErrMissionNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusNotFound, ErrorObj(utils.ErrMissionNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	body, err := json.Marshal(collection)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.Data(http.StatusOK, geoJSONContentType, body)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
@@ -0,0 +1,244 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/domain/template"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/transport/dto"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/internal/utils"
+	"github.com/PureTeamLead/go-test-assessment-developstoday/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+const (
+	templateIDParam = "template-id"
+)
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, req template.CreateTemplateSvc) (uuid.UUID, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (*template.Template, error)
+	ListTemplates(ctx context.Context) ([]*template.Template, error)
+	UpdateTemplate(ctx context.Context, params template.UpdateTemplateParams) (*template.Template, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+}
+
+func (h *Handler) CreateTemplate(c *gin.Context) {
+	const op = "handler.CreateTemplate"
+
+	var req dto.CreateTemplateReq
+	if err := c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	id, err := h.TemplateService.CreateTemplate(h.Ctx, template.CreateTemplateSvc{
+		Name:         req.Name,
+		MissionType:  req.MissionType,
+		DefaultNotes: req.DefaultNotes,
+		Targets:      dto.MapSkeletons(req.Targets),
+	})
+	switch {
+	case errors.Is(err, utils.ErrValidatingTemplate):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("failed to pass validation on template object"))
+		return
+	case errors.Is(err, utils.ErrNoTargets):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("no targets were specified"))
+		return
+	case errors.Is(err, utils.ErrTargetOverflow):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("too much targets specified"))
+		return
+	case errors.Is(err, utils.ErrConflictingData):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("duplicate of unique data"))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
+
+func (h *Handler) ListTemplates(c *gin.Context) {
+	const op = "handler.ListTemplates"
+
+	templates, err := h.TemplateService.ListTemplates(h.Ctx)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) GetTemplate(c *gin.Context) {
+	const op = "handler.GetTemplate"
+
+	id := c.Param(idParam)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	tmpl, err := h.TemplateService.GetTemplate(h.Ctx, parsedID)
+	if err != nil {
+		if errors.Is(err, utils.ErrTemplateNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTemplateNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, tmpl)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) UpdateTemplate(c *gin.Context) {
+	const op = "handler.UpdateTemplate"
+
+	id := c.Param(idParam)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.UpdateTemplateReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on put request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	updatedTemplate, err := h.TemplateService.UpdateTemplate(h.Ctx, template.UpdateTemplateParams{
+		ID:           parsedID,
+		Name:         req.Name,
+		MissionType:  req.MissionType,
+		DefaultNotes: req.DefaultNotes,
+		Targets:      dto.MapSkeletons(req.Targets),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, utils.ErrValidatingTemplate):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("failed to pass validation on template object"))
+			return
+		case errors.Is(err, utils.ErrNoTargets), errors.Is(err, utils.ErrTargetOverflow):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("template must have between one and three targets"))
+			return
+		case errors.Is(err, utils.ErrConflictingData):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj("duplicate of unique data"))
+			return
+		case errors.Is(err, utils.ErrTemplateNotFound):
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTemplateNotFound.Error()))
+			return
+		default:
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusInternalServerError, InternalErrorObj())
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, updatedTemplate)
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) DeleteTemplate(c *gin.Context) {
+	const op = "handler.DeleteTemplate"
+
+	id := c.Param(idParam)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	if err = h.TemplateService.DeleteTemplate(h.Ctx, parsedID); err != nil {
+		if errors.Is(err, utils.ErrTemplateNotFound) {
+			logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+			c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTemplateNotFound.Error()))
+			return
+		}
+
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]interface{}{"status": "success on template deletion operation"})
+	logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+}
+
+func (h *Handler) CreateMissionFromTemplate(c *gin.Context) {
+	const op = "handler.CreateMissionFromTemplate"
+
+	rawTemplateID := c.Param(templateIDParam)
+	templateID, err := uuid.Parse(rawTemplateID)
+	if err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidID.Error()))
+		return
+	}
+
+	var req dto.CreateFromTemplateReq
+	if err = c.BindJSON(&req); err != nil {
+		logger.GetLoggerFromCtx(h.Ctx).Error(fmt.Sprintf("%s: failed to map input on post request", op), err)
+		c.JSON(http.StatusBadRequest, BadRequestObj())
+		return
+	}
+
+	id, err := h.MisTargetService.CreateMissionFromTemplate(h.actorCtx(c), templateID, dto.MapFromTemplateToRaw(req))
+	switch {
+	case errors.Is(err, utils.ErrTemplateNotFound):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrTemplateNotFound.Error()))
+		return
+	case errors.Is(err, utils.ErrUnknownOverride):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownOverride.Error()))
+		return
+	case errors.Is(err, utils.ErrInvalidDeadline):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrInvalidDeadline.Error()))
+		return
+	case errors.Is(err, utils.ErrUnknownCountry):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj(utils.ErrUnknownCountry.Error()))
+		return
+	case errors.Is(err, utils.ErrValidatingTargets):
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusBadRequest, ErrorObj("failed to pass validation on target object"))
+		return
+	case err == nil:
+		logger.GetLoggerFromCtx(h.Ctx).Info(fmt.Sprintf("%s: success", op))
+		c.JSON(http.StatusCreated, map[string]interface{}{"obj_id": id})
+		return
+	default:
+		logger.GetLoggerFromCtx(h.Ctx).Error(op, err)
+		c.JSON(http.StatusInternalServerError, InternalErrorObj())
+		return
+	}
+}
@@ -3,18 +3,70 @@ package utils
 import "errors"
 
 var (
-	ErrValidatingCat     = errors.New("invalid cat input structure")
-	ErrCatNotFound       = errors.New("cat not found")
-	ErrConflictingData   = errors.New("conflict of data occurred")
-	ErrMissionNotFound   = errors.New("mission not found")
-	ErrTargetNotFound    = errors.New("target not found")
-	ErrInvalidBreed      = errors.New("invalid breed")
-	ErrApiServerError    = errors.New("api server error")
-	ErrNoTargets         = errors.New("empty targets")
-	ErrValidatingTargets = errors.New("failed to validate and create targets")
-	ErrCatAssigned       = errors.New("cat is already assigned to the mission, operation is impossible")
-	ErrMissionCompleted  = errors.New("mission is already completed, operation is impossible")
-	ErrTargetCompleted   = errors.New("target is already completed, operation is impossible")
-	ErrInvalidID         = errors.New("invalid ID format")
-	ErrTargetOverflow    = errors.New("too much target in one mission")
+	ErrValidatingCat      = errors.New("invalid cat input structure")
+	ErrCatNotFound        = errors.New("cat not found")
+	ErrConflictingData    = errors.New("conflict of data occurred")
+	ErrMissionNotFound    = errors.New("mission not found")
+	ErrTargetNotFound     = errors.New("target not found")
+	ErrInvalidBreed       = errors.New("invalid breed")
+	ErrApiServerError     = errors.New("api server error")
+	ErrNoTargets          = errors.New("empty targets")
+	ErrValidatingTargets  = errors.New("failed to validate and create targets")
+	ErrCatAssigned        = errors.New("cat is already assigned to the mission, operation is impossible")
+	ErrMissionCompleted   = errors.New("mission is already completed, operation is impossible")
+	ErrTargetCompleted    = errors.New("target is already completed, operation is impossible")
+	ErrInvalidID          = errors.New("invalid ID format")
+	ErrTargetOverflow     = errors.New("too much target in one mission")
+	ErrInvalidDeadline    = errors.New("mission deadline must be after its start time")
+	ErrCatUnavailable     = errors.New("cat is already busy with another active mission")
+	ErrNoAvailableCats    = errors.New("no cats available for assignment")
+	ErrTemplateNotFound   = errors.New("mission template not found")
+	ErrValidatingTemplate = errors.New("invalid mission template structure")
+	ErrInvalidQuery       = errors.New("invalid missions query parameters")
+	ErrInvalidCursor      = errors.New("invalid pagination cursor")
+	ErrInvalidBudget      = errors.New("mission budget can not be negative")
+	ErrInvalidExpense     = errors.New("invalid expense structure")
+	ErrInvalidPeriod      = errors.New("invalid report period")
+	ErrUnknownOverride    = errors.New("override refers to a target missing in the template")
+	ErrExpenseNotFound    = errors.New("expense not found")
+	ErrExpenseTransition  = errors.New("expense status change is not allowed")
+	ErrExpenseClosed      = errors.New("expenses can no longer be claimed for this mission")
+	ErrExpenseClaimant    = errors.New("expenses can only be claimed by the cat assigned to the mission")
+	ErrReceiptNotFound    = errors.New("expense has no receipt")
+	ErrReceiptTooLarge    = errors.New("receipt file is too large")
+	ErrMissionAborted     = errors.New("mission is aborted, operation is impossible")
+	ErrTargetAbandoned    = errors.New("target is abandoned, operation is impossible")
+	ErrInvalidAbortReason = errors.New("unknown abort reason")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle between missions")
+	ErrDependencyNotFound = errors.New("mission dependency not found")
+	ErrMissionBlocked     = errors.New("mission has prerequisites that are not completed yet")
+	ErrUnsupportedFormat  = errors.New("unsupported export format")
+	ErrUnknownExportTmpl  = errors.New("export template not found")
+	ErrInvalidCron        = errors.New("invalid cron expression")
+	ErrValidatingSchedule = errors.New("invalid mission schedule structure")
+	ErrScheduleNotFound   = errors.New("mission schedule not found")
+	ErrMissionNotApproved = errors.New("mission is not approved")
+	ErrApprovalClosed     = errors.New("mission approval is already settled")
+	ErrAlreadyDecided     = errors.New("approver has already decided on the mission")
+	ErrApproverRequired   = errors.New("approver identity is required")
+	ErrRevisionNotFound   = errors.New("notes revision not found")
+	ErrEntryNotFound      = errors.New("journal entry not found")
+	ErrInvalidEntry       = errors.New("invalid journal entry structure")
+	ErrInvalidSighting    = errors.New("invalid sighting structure")
+	ErrInvalidCoordinates = errors.New("latitude must be within [-90, 90] and longitude within [-180, 180]")
+	ErrOutsideCountry     = errors.New("sighting coordinates are outside the target country")
+	ErrUnknownCountry     = errors.New("unknown country, expected an ISO 3166-1 name, alpha-2 or alpha-3 code")
+	ErrInvalidDossier     = errors.New("invalid dossier structure")
+	ErrDossierNotFound    = errors.New("dossier not found")
+	ErrTargetNotLinked    = errors.New("target is not linked to the dossier")
+	ErrLastTarget         = errors.New("mission must keep at least one target")
+	ErrSameMission        = errors.New("target already belongs to the mission")
+	ErrTargetClosed       = errors.New("target is neutralized or escaped, operation is impossible")
+	ErrUnknownTargetState = errors.New("unknown target state")
+	ErrTargetTransition   = errors.New("target state change is not allowed")
+	ErrInvalidSearch      = errors.New("search query must not be empty and limit must be within [1, 100]")
+	ErrUnknownNotesKey    = errors.New("notes are encrypted with a key that is not configured")
+	ErrNotesDecryption    = errors.New("failed to decrypt target notes")
+	ErrOccurrenceFailed   = errors.New("schedule occurrence failed too many times")
+	ErrPrerequisiteFailed = errors.New("mission has a prerequisite that was aborted or deleted, remove the dependency to unblock it")
 )